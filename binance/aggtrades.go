@@ -0,0 +1,202 @@
+// The MIT License (MIT)
+//
+// Copyright (c) 2018 Cranky Kernel
+//
+// Permission is hereby granted, free of charge, to any person
+// obtaining a copy of this software and associated documentation
+// files (the "Software"), to deal in the Software without
+// restriction, including without limitation the rights to use, copy,
+// modify, merge, publish, distribute, sublicense, and/or sell copies
+// of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be
+// included in all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND,
+// EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF
+// MERCHANTABILITY, FITNESS FOR A PARTICULAR PURPOSE AND
+// NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT HOLDERS
+// BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER IN AN
+// ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN
+// CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package binance
+
+import (
+	"encoding/json"
+	"net/http"
+	"time"
+
+	"gitlab.com/crankykernel/cryptotrader/util"
+)
+
+// The maximum number of trades returned by a single aggTrades request.
+const AGG_TRADES_MAX_LIMIT = 1000
+
+type AggTradesParameters struct {
+	Symbol string
+
+	// Return trades with an aggregate trade ID greater than or equal to
+	// FromId. As 0 is a valid trade ID, FromId is only sent if it is not
+	// negative, so set it to -1 when querying by time.
+	FromId int64
+
+	// If both are set the range may be at most one hour.
+	StartTime time.Time
+	EndTime   time.Time
+
+	Limit int64
+}
+
+// GetAggTrades returns aggregate trades, in the same form as the
+// <symbol>@aggTrade stream. If neither FromId nor a time range is set the
+// most recent trades are returned.
+func (c *RestClient) GetAggTrades(query AggTradesParameters) ([]StreamAggTrade, error) {
+	params := map[string]interface{}{
+		"symbol": query.Symbol,
+	}
+	if query.FromId > -1 {
+		params["fromId"] = query.FromId
+	}
+	if !query.StartTime.IsZero() {
+		params["startTime"] = util.TimeToMillis(query.StartTime)
+	}
+	if !query.EndTime.IsZero() {
+		params["endTime"] = util.TimeToMillis(query.EndTime)
+	}
+	if query.Limit > 0 {
+		params["limit"] = query.Limit
+	}
+
+	httpResponse, err := c.Get("/api/v3/aggTrades", params)
+	if err != nil {
+		return nil, err
+	}
+	defer httpResponse.Body.Close()
+	if httpResponse.StatusCode != http.StatusOK {
+		return nil, NewRestApiErrorFromResponse(httpResponse)
+	}
+	var trades []StreamAggTrade
+	if err := json.NewDecoder(httpResponse.Body).Decode(&trades); err != nil {
+		return nil, err
+	}
+
+	// The REST response does not include the event fields of the stream.
+	for i := range trades {
+		trades[i].EventType = "aggTrade"
+		trades[i].Symbol = query.Symbol
+	}
+	return trades, nil
+}
+
+// DownloadAggTrades calls fn with pages of the aggregate trades for symbol
+// made between start and end, in trade ID order. Each trade is passed to fn
+// exactly once. A zero start downloads from the first trade and a zero end
+// downloads up to the most recent trade.
+//
+// The first trade at or after start is found by a binary search on trade
+// ID, the rest are paged through by trade ID.
+func (c *RestClient) DownloadAggTrades(symbol string, start time.Time, end time.Time, fn func([]StreamAggTrade) error) error {
+	if start.IsZero() {
+		return c.DownloadAggTradesFromId(symbol, 0, end, fn)
+	}
+	fromId, err := c.findAggTradeId(symbol, start)
+	if err != nil || fromId < 0 {
+		return err
+	}
+	return c.DownloadAggTradesFromId(symbol, fromId, end, fn)
+}
+
+// findAggTradeId returns the ID of the first aggregate trade made at or
+// after start, or -1 if there is none.
+func (c *RestClient) findAggTradeId(symbol string, start time.Time) (int64, error) {
+	startMillis := util.TimeToMillis(start)
+
+	// firstFrom returns the first trade with an ID of at least fromId, or
+	// the most recent trade if fromId is negative.
+	firstFrom := func(fromId int64) (*StreamAggTrade, error) {
+		trades, err := c.GetAggTrades(AggTradesParameters{
+			Symbol: symbol,
+			FromId: fromId,
+			Limit:  1,
+		})
+		if err != nil || len(trades) == 0 {
+			return nil, err
+		}
+		return &trades[0], nil
+	}
+
+	last, err := firstFrom(-1)
+	if err != nil || last == nil || last.TradeTimeMillis < startMillis {
+		return -1, err
+	}
+	first, err := firstFrom(0)
+	if err != nil || first == nil {
+		return -1, err
+	}
+	if first.TradeTimeMillis >= startMillis {
+		return first.TradeID, nil
+	}
+
+	// The trade at lo is before start and the trade at hi is not.
+	lo, hi := first.TradeID, last.TradeID
+	for hi-lo > 1 {
+		mid := lo + (hi-lo)/2
+		trade, err := firstFrom(mid)
+		if err != nil {
+			return -1, err
+		}
+		if trade == nil || trade.TradeTimeMillis >= startMillis {
+			hi = mid
+		} else {
+			lo = trade.TradeID
+		}
+	}
+	return hi, nil
+}
+
+// DownloadAggTradesFromId calls fn with pages of the aggregate trades for
+// symbol starting at trade ID fromId up to end, or the most recent trade if
+// end is zero.
+func (c *RestClient) DownloadAggTradesFromId(symbol string, fromId int64, end time.Time, fn func([]StreamAggTrade) error) error {
+	endMillis := util.TimeToMillis(end)
+	lastId := fromId - 1
+	for {
+		trades, err := c.GetAggTrades(AggTradesParameters{
+			Symbol: symbol,
+			FromId: lastId + 1,
+			Limit:  AGG_TRADES_MAX_LIMIT,
+		})
+		if err != nil {
+			return err
+		}
+
+		// Drop any trades already seen, and stop at the first trade
+		// after the end time.
+		page := make([]StreamAggTrade, 0, len(trades))
+		done := len(trades) < AGG_TRADES_MAX_LIMIT
+		for _, trade := range trades {
+			if trade.TradeID <= lastId {
+				continue
+			}
+			if !end.IsZero() && trade.TradeTimeMillis > endMillis {
+				done = true
+				break
+			}
+			page = append(page, trade)
+			lastId = trade.TradeID
+		}
+
+		if len(page) == 0 {
+			return nil
+		}
+		if err := fn(page); err != nil {
+			return err
+		}
+		if done {
+			return nil
+		}
+	}
+}
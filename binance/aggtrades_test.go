@@ -0,0 +1,116 @@
+package binance
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+	"time"
+
+	"gitlab.com/crankykernel/cryptotrader/util"
+)
+
+func TestDownloadAggTrades(t *testing.T) {
+	// 2500 trades, one every 10 seconds starting 5 hours after first,
+	// with IDs starting at 100.
+	first := util.TimeToMillis(time.Date(2018, 5, 1, 0, 0, 0, 0, time.UTC))
+	tradeTime := func(id int64) int64 {
+		return first + 5*3600*1000 + (id-100)*10000
+	}
+
+	requests := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		query := r.URL.Query()
+		limit, _ := strconv.ParseInt(query.Get("limit"), 10, 64)
+		fromId, _ := strconv.ParseInt(query.Get("fromId"), 10, 64)
+		startTime, _ := strconv.ParseInt(query.Get("startTime"), 10, 64)
+		endTime := tradeTime(2599)
+		if value := query.Get("endTime"); value != "" {
+			endTime, _ = strconv.ParseInt(value, 10, 64)
+		}
+		if query.Get("startTime") != "" && endTime-startTime >= 3600*1000 {
+			http.Error(w, `{"code":-1127,"msg":"More than 1 hours between startTime and endTime."}`,
+				http.StatusBadRequest)
+			return
+		}
+		trades := []map[string]interface{}{}
+		if query.Get("fromId") == "" && query.Get("startTime") == "" {
+			// The most recent trades.
+			fromId = 2600 - limit
+		}
+		for id := int64(100); id < 2600 && int64(len(trades)) < limit; id++ {
+			if id < fromId || tradeTime(id) < startTime || tradeTime(id) > endTime {
+				continue
+			}
+			trades = append(trades, map[string]interface{}{
+				"a": id, "p": "0.01", "q": "1", "f": id, "l": id,
+				"T": tradeTime(id), "m": true, "M": true,
+			})
+		}
+		json.NewEncoder(w).Encode(trades)
+	}))
+	defer server.Close()
+
+	client := NewAnonymousClient(WithRestUrl(server.URL), WithRateLimiter(nil))
+
+	collect := func(start time.Time, end time.Time) []StreamAggTrade {
+		trades := []StreamAggTrade{}
+		err := client.DownloadAggTrades("ETHBTC", start, end, func(page []StreamAggTrade) error {
+			trades = append(trades, page...)
+			return nil
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		return trades
+	}
+
+	trades := collect(time.Time{}, time.Time{})
+	if len(trades) != 2500 || requests != 3 {
+		t.Fatalf("expected 2500 trades in 3 requests, got %d in %d", len(trades), requests)
+	}
+	for i, trade := range trades {
+		if trade.TradeID != int64(100+i) || trade.Symbol != "ETHBTC" {
+			t.Fatalf("unexpected trade %d: %+v", i, trade)
+		}
+	}
+
+	// Starting years before the first trade only needs the first and most
+	// recent trades to find where to start.
+	requests = 0
+	start := util.MillisToTime(first).AddDate(-5, 0, 0)
+	end := util.MillisToTime(tradeTime(1599))
+	trades = collect(start, end)
+	if len(trades) != 1500 || trades[0].TradeID != 100 || trades[1499].TradeID != 1599 {
+		t.Fatalf("expected trades 100 to 1599, got %d trades", len(trades))
+	}
+	if requests != 4 {
+		t.Errorf("expected 4 requests, got %d", requests)
+	}
+
+	// Starting between trades binary searches for the first trade.
+	for _, id := range []int64{101, 1234, 2599} {
+		requests = 0
+		trades = collect(util.MillisToTime(tradeTime(id)-5000), time.Time{})
+		if len(trades) != int(2600-id) || trades[0].TradeID != id {
+			t.Fatalf("expected trades %d to 2599, got %d trades", id, len(trades))
+		}
+		if requests > 16 {
+			t.Errorf("expected at most 16 requests, got %d", requests)
+		}
+	}
+
+	// Starting after the most recent trade.
+	trades = collect(util.MillisToTime(tradeTime(2599)+1), time.Time{})
+	if len(trades) != 0 {
+		t.Errorf("expected no trades, got %d", len(trades))
+	}
+
+	// No trades in the range.
+	trades = collect(util.MillisToTime(first), util.MillisToTime(first+3600*1000))
+	if len(trades) != 0 {
+		t.Errorf("expected no trades, got %d", len(trades))
+	}
+}
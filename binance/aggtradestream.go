@@ -25,6 +25,7 @@
 package binance
 
 import (
+	"context"
 	"fmt"
 	"strings"
 	"github.com/gorilla/websocket"
@@ -36,33 +37,62 @@ type AggTradeStreamEvent struct {
 	Trade *StreamAggTrade
 }
 
+// AggTradeStream reads the <symbol>@aggTrade stream from a single
+// connection, which Binance closes after 24 hours. Long running consumers
+// should use a ManagedAggTradeStream.
 type AggTradeStream struct {
 	ws             *websocket.Conn
 	closeRequested bool
+	closed         chan struct{}
 }
 
-func OpenAggTradeStream(symbol string) (*AggTradeStream, error) {
-	ws, err := openStream(fmt.Sprintf("ws/%s@aggTrade", strings.ToLower(symbol)))
+func OpenAggTradeStream(symbol string, options ...ClientOption) (*AggTradeStream, error) {
+	return OpenAggTradeStreamContext(context.Background(), symbol, options...)
+}
+
+// OpenAggTradeStreamContext opens an AggTradeStream that is closed when ctx
+// is done.
+func OpenAggTradeStreamContext(ctx context.Context, symbol string, options ...ClientOption) (*AggTradeStream, error) {
+	ws, err := openStreamContext(ctx, newClientConfig(options...),
+		fmt.Sprintf("ws/%s@aggTrade", strings.ToLower(symbol)))
 	if err != nil {
 		return nil, err
 	}
-	return &AggTradeStream{
+	stream := &AggTradeStream{
 		ws:             ws,
 		closeRequested: false,
-	}, nil
+		closed:         make(chan struct{}),
+	}
+	closeOnDone(ctx, ws, stream.closed)
+	return stream, nil
 }
 
 // Close closes the AggTradeStream. If there is a subscribed channel it will
 // no longer be sent any data. So it is up to the subscriber to stop attempting
 // to read from the channel.
 func (c *AggTradeStream) Close() {
+	if !c.closeRequested {
+		close(c.closed)
+	}
 	c.closeRequested = true
 	c.ws.Close()
 }
 
 func (c *AggTradeStream) Next() (trade *StreamAggTrade, err error) {
+	return c.NextContext(context.Background())
+}
+
+// NextContext reads the next trade. If ctx is done before a trade is read
+// the stream is closed and ctx.Err() is returned.
+func (c *AggTradeStream) NextContext(ctx context.Context) (trade *StreamAggTrade, err error) {
+	stop := make(chan struct{})
+	closeOnDone(ctx, c.ws, stop)
 	_, buf, err := c.ws.ReadMessage()
+	close(stop)
 	if err != nil {
+		if ctx.Err() != nil {
+			return nil, ctx.Err()
+		}
 		return nil, err
 	}
 	var rawAggTrade StreamAggTrade
@@ -73,14 +103,22 @@ func (c *AggTradeStream) Next() (trade *StreamAggTrade, err error) {
 }
 
 func (c *AggTradeStream) Subscribe(channel chan AggTradeStreamEvent) {
+	c.SubscribeContext(context.Background(), channel)
+}
+
+// SubscribeContext sends trades to channel until the stream fails, is
+// closed or ctx is done. When ctx is done the stream is closed and
+// ctx.Err() is returned without sending anything further to channel.
+// Otherwise it behaves like Subscribe and returns the stream error, or nil
+// if the stream was closed.
+func (c *AggTradeStream) SubscribeContext(ctx context.Context, channel chan AggTradeStreamEvent) error {
 	for {
-		trade, err := c.Next()
+		trade, err := c.NextContext(ctx)
 		if err != nil {
-			if !c.closeRequested {
-				channel <- AggTradeStreamEvent{
-					Err: err,
-				}
-			} else {
+			if ctx.Err() != nil {
+				return ctx.Err()
+			}
+			if c.closeRequested {
 				// Close was requested. Attempt to send down a nil, nil message
 				// as a signal that the stream has now been closed.
 				select {
@@ -90,12 +128,89 @@ func (c *AggTradeStream) Subscribe(channel chan AggTradeStreamEvent) {
 				}:
 				default:
 				}
+				return nil
+			}
+			select {
+			case channel <- AggTradeStreamEvent{
+				Err: err,
+			}:
+			case <-ctx.Done():
+				return ctx.Err()
 			}
-			return
+			return err
 		} else {
-			channel <- AggTradeStreamEvent{
+			select {
+			case channel <- AggTradeStreamEvent{
 				Trade: trade,
+			}:
+			case <-ctx.Done():
+				c.ws.Close()
+				return ctx.Err()
 			}
 		}
 	}
 }
+
+// ManagedAggTradeStream follows the <symbol>@aggTrade stream over a
+// ManagedStream, so unlike AggTradeStream it survives dropped connections
+// and the 24 hour connection limit. It sends the same events as
+// AggTradeStream.Subscribe.
+type ManagedAggTradeStream struct {
+	// The underlying stream, its settings may be changed before calling
+	// Run.
+	Stream *ManagedStream
+}
+
+func NewManagedAggTradeStream(symbol string, options ...ClientOption) *ManagedAggTradeStream {
+	stream := NewManagedStream([]string{
+		fmt.Sprintf("%s@aggTrade", strings.ToLower(symbol))}, options...)
+	stream.Single = true
+	return &ManagedAggTradeStream{
+		Stream: stream,
+	}
+}
+
+// Run sends trades to channel until ctx is done and returns ctx.Err(). A
+// lost connection is sent as an event with Err set, the stream then
+// reconnects and trades follow again.
+func (s *ManagedAggTradeStream) Run(ctx context.Context, channel chan AggTradeStreamEvent) error {
+	send := func(event AggTradeStreamEvent) error {
+		select {
+		case channel <- event:
+			return nil
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+
+	events := make(chan ManagedStreamEvent)
+	result := make(chan error, 1)
+	go func() {
+		result <- s.Stream.Run(ctx, events)
+	}()
+
+	for {
+		var event ManagedStreamEvent
+		select {
+		case event = <-events:
+		case err := <-result:
+			return err
+		}
+
+		var err error
+		switch event.Type {
+		case ManagedStreamDisconnected:
+			err = send(AggTradeStreamEvent{Err: event.Err})
+		case ManagedStreamMessage:
+			var trade StreamAggTrade
+			if json.Unmarshal(event.Message, &trade) != nil || trade.EventType != "aggTrade" {
+				// Anything that isn't a trade is ignored.
+				continue
+			}
+			err = send(AggTradeStreamEvent{Trade: &trade})
+		}
+		if err != nil {
+			return err
+		}
+	}
+}
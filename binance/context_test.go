@@ -0,0 +1,100 @@
+package binance
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gorilla/websocket"
+)
+
+func TestRestClientContext(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// Hang until the client goes away.
+		<-r.Context().Done()
+	}))
+	defer server.Close()
+
+	client := NewAnonymousClient(WithRestUrl(server.URL), WithRateLimiter(nil))
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	started := time.Now()
+	_, err := client.WithContext(ctx).GetServerTime()
+	if err == nil {
+		t.Fatal("expected an error")
+	}
+	if elapsed := time.Since(started); elapsed > 5*time.Second {
+		t.Errorf("request was not aborted, took %v", elapsed)
+	}
+	if client.Context() != context.Background() {
+		t.Errorf("expected the original client to be unchanged")
+	}
+}
+
+func TestRateLimiterAcquireContext(t *testing.T) {
+	limiter := NewRateLimiter(RateLimitModeBlock)
+	limiter.SetLimits([]RateLimit{
+		{RateLimitTypeRequestWeight, "MINUTE", 1, 10},
+	})
+	if err := limiter.Acquire(10, 0); err != nil {
+		t.Fatal(err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	if err := limiter.AcquireContext(ctx, 5, 0); err != context.DeadlineExceeded {
+		t.Errorf("expected context.DeadlineExceeded, got %v", err)
+	}
+}
+
+func TestAggTradeStreamSubscribeContext(t *testing.T) {
+	upgrader := websocket.Upgrader{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ws, err := upgrader.Upgrade(w, r, nil)
+		if err != nil {
+			return
+		}
+		defer ws.Close()
+		ws.WriteMessage(websocket.TextMessage,
+			[]byte(`{"e":"aggTrade","s":"ETHBTC","a":1,"p":"0.03","q":"1"}`))
+		// Then send nothing until the client closes the connection.
+		for {
+			if _, _, err := ws.ReadMessage(); err != nil {
+				return
+			}
+		}
+	}))
+	defer server.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	stream, err := OpenAggTradeStreamContext(ctx, "ETHBTC",
+		WithStreamUrl("ws"+strings.TrimPrefix(server.URL, "http")))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	channel := make(chan AggTradeStreamEvent, 1)
+	result := make(chan error, 1)
+	go func() {
+		result <- stream.SubscribeContext(ctx, channel)
+	}()
+
+	event := <-channel
+	if event.Err != nil || event.Trade == nil || event.Trade.TradeID != 1 {
+		t.Fatalf("unexpected event: %+v", event)
+	}
+
+	cancel()
+	select {
+	case err := <-result:
+		if err != context.Canceled {
+			t.Errorf("expected context.Canceled, got %v", err)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("subscribe did not return after the context was cancelled")
+	}
+}
@@ -0,0 +1,208 @@
+// The MIT License (MIT)
+//
+// Copyright (c) 2018 Cranky Kernel
+//
+// Permission is hereby granted, free of charge, to any person
+// obtaining a copy of this software and associated documentation
+// files (the "Software"), to deal in the Software without
+// restriction, including without limitation the rights to use, copy,
+// modify, merge, publish, distribute, sublicense, and/or sell copies
+// of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be
+// included in all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND,
+// EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF
+// MERCHANTABILITY, FITNESS FOR A PARTICULAR PURPOSE AND
+// NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT HOLDERS
+// BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER IN AN
+// ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN
+// CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package binance
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+// OrderDryRun is the result of rehearsing an order without placing it.
+type OrderDryRun struct {
+	// The request that would place the order, with the signature redacted.
+	Method string
+	Url    string
+
+	// Violations found validating the order against the symbol filters.
+	Violations []FilterViolation
+
+	// Whether the order was sent to the test order endpoint. OCO orders have
+	// no test endpoint so are only validated locally.
+	Tested bool
+
+	// The error returned by the test order endpoint, nil if the order was
+	// accepted.
+	TestError error
+}
+
+// Ok returns true if the order passed local validation and, if tested, was
+// accepted by the test order endpoint.
+func (r *OrderDryRun) Ok() bool {
+	return len(r.Violations) == 0 && r.TestError == nil
+}
+
+func (r *OrderDryRun) String() string {
+	lines := []string{fmt.Sprintf("%s %s", r.Method, r.Url)}
+	if len(r.Violations) == 0 {
+		lines = append(lines, "validation: passed")
+	} else {
+		lines = append(lines, "validation: failed")
+		for _, violation := range r.Violations {
+			lines = append(lines, "  "+violation.String())
+		}
+	}
+	switch {
+	case !r.Tested:
+		lines = append(lines, "test order: not available")
+	case r.TestError != nil:
+		lines = append(lines, fmt.Sprintf("test order: rejected: %v", r.TestError))
+	default:
+		lines = append(lines, "test order: accepted")
+	}
+	return strings.Join(lines, "\n")
+}
+
+// TestOrder rehearses an order: it is validated against the symbol filters
+// and sent to the test order endpoint, which checks it like a real order
+// but does not place it.
+func (c *RestClient) TestOrder(order OrderParameters) (*OrderDryRun, error) {
+	params, err := order.Params()
+	if err != nil {
+		return nil, err
+	}
+	dryRun := c.newDryRun("POST", "/api/v3/order", params)
+
+	validator, context, err := c.orderValidator(order.Symbol)
+	if err != nil {
+		return nil, err
+	}
+	if err := validator.Validate(order, context); err != nil {
+		dryRun.Violations = err.(*OrderValidationError).Violations
+	}
+
+	response, err := c.Post("/api/v3/order/test", params)
+	if err != nil {
+		return nil, err
+	}
+	defer response.Body.Close()
+	dryRun.Tested = true
+	if response.StatusCode != http.StatusOK {
+		dryRun.TestError = NewRestApiErrorFromResponse(response)
+	}
+	return dryRun, nil
+}
+
+// TestOcoOrder rehearses an OCO order. There is no test endpoint for OCO
+// orders, so each order in the list is only validated locally.
+func (c *RestClient) TestOcoOrder(order OcoOrderParameters) (*OrderDryRun, error) {
+	params, err := order.Params()
+	if err != nil {
+		return nil, err
+	}
+	dryRun := c.newDryRun("POST", "/api/v3/order/oco", params)
+
+	validator, context, err := c.orderValidator(order.Symbol)
+	if err != nil {
+		return nil, err
+	}
+	symbol, ok := validator.GetSymbol(order.Symbol)
+	if !ok {
+		dryRun.Violations = []FilterViolation{{checkSymbol, "unknown symbol"}}
+		return dryRun, nil
+	}
+	if !symbol.OcoAllowed {
+		dryRun.Violations = append(dryRun.Violations,
+			FilterViolation{checkOrderType, "oco orders not allowed"})
+	}
+	for _, leg := range order.orders() {
+		err := validator.Validate(leg, context)
+		if err == nil {
+			continue
+		}
+		for _, violation := range err.(*OrderValidationError).Violations {
+			// The order types of an OCO order are not restricted by the
+			// order types of the symbol.
+			if violation.Filter == checkOrderType {
+				continue
+			}
+			violation.Message = fmt.Sprintf("%s order: %s", leg.Type, violation.Message)
+			dryRun.Violations = append(dryRun.Violations, violation)
+		}
+	}
+	return dryRun, nil
+}
+
+// orders returns the limit maker and stop orders making up an OCO order.
+func (order OcoOrderParameters) orders() []OrderParameters {
+	limit := OrderParameters{
+		Symbol:     order.Symbol,
+		Side:       order.Side,
+		Type:       OrderTypeLimitMaker,
+		Quantity:   order.Quantity,
+		Price:      order.Price,
+		IcebergQty: order.LimitIcebergQty,
+	}
+	stop := OrderParameters{
+		Symbol:    order.Symbol,
+		Side:      order.Side,
+		Type:      OrderTypeStopLoss,
+		Quantity:  order.Quantity,
+		StopPrice: order.StopPrice,
+	}
+	if order.StopLimitPrice.Sign() > 0 {
+		stop.Type = OrderTypeStopLossLimit
+		stop.Price = order.StopLimitPrice
+		stop.TimeInForce = order.StopLimitTimeInForce
+		stop.IcebergQty = order.StopIcebergQty
+	}
+	return []OrderParameters{limit, stop}
+}
+
+// orderValidator returns a validator and context for validating orders on
+// a symbol, using the current exchange info and average price.
+func (c *RestClient) orderValidator(symbol string) (*OrderValidator, OrderValidationContext, error) {
+	context := OrderValidationContext{}
+	exchangeInfo, err := c.GetSymbolExchangeInfo(symbol)
+	if err != nil {
+		return nil, context, err
+	}
+	averagePrice, err := c.GetAveragePrice(symbol)
+	if err != nil {
+		return nil, context, err
+	}
+	context.AveragePrice = averagePrice.Price
+	return NewOrderValidator(exchangeInfo), context, nil
+}
+
+// newDryRun describes the request that would be sent, with the signature
+// redacted so the description can be logged.
+func (c *RestClient) newDryRun(method string, endpoint string, params map[string]interface{}) *OrderDryRun {
+	copied := map[string]interface{}{}
+	for key, value := range params {
+		copied[key] = value
+	}
+	return &OrderDryRun{
+		Method: method,
+		Url:    redactSignature(c.buildUrl(endpoint, copied, c.canSign())),
+	}
+}
+
+func redactSignature(url string) string {
+	if i := strings.Index(url, "signature="); i > -1 {
+		return url[:i+len("signature=")] + "<redacted>"
+	}
+	return url
+}
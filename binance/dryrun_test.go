@@ -0,0 +1,73 @@
+package binance
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"gitlab.com/crankykernel/cryptotrader/decimal"
+)
+
+func TestTestOrder(t *testing.T) {
+	paths := []string{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		paths = append(paths, r.Method+" "+r.URL.Path)
+		switch r.URL.Path {
+		case "/api/v1/exchangeInfo":
+			w.Write([]byte(testExchangeInfo))
+		case "/api/v3/avgPrice":
+			w.Write([]byte(`{"mins":5,"price":"0.03"}`))
+		case "/api/v3/order/test":
+			if r.URL.Query().Get("price") == "0.031" {
+				w.Write([]byte("{}"))
+				return
+			}
+			w.WriteHeader(http.StatusBadRequest)
+			w.Write([]byte(`{"code":-1013,"msg":"Filter failure: PRICE_FILTER"}`))
+		default:
+			t.Errorf("unexpected request: %s", r.URL.Path)
+		}
+	}))
+	defer server.Close()
+
+	client := NewAuthenticatedClient("key", "secret",
+		WithRestUrl(server.URL),
+		WithTimeSync(nil),
+		WithRateLimiter(nil))
+
+	order := OrderParameters{
+		Symbol:   "ETHBTC",
+		Side:     OrderSideBuy,
+		Type:     OrderTypeLimit,
+		Quantity: decimal.MustParse("1"),
+		Price:    decimal.MustParse("0.031"),
+	}
+	dryRun, err := client.TestOrder(order)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !dryRun.Ok() || !dryRun.Tested {
+		t.Errorf("expected order to pass: %s", dryRun)
+	}
+	if !strings.HasPrefix(dryRun.Url, server.URL+"/api/v3/order?") {
+		t.Errorf("unexpected url: %s", dryRun.Url)
+	}
+	if !strings.HasSuffix(dryRun.Url, "&signature=<redacted>") {
+		t.Errorf("expected signature to be redacted: %s", dryRun.Url)
+	}
+	for _, path := range paths {
+		if path == "POST /api/v3/order" {
+			t.Errorf("order was placed")
+		}
+	}
+
+	order.Price = decimal.MustParse("0.0310001")
+	dryRun, err = client.TestOrder(order)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if dryRun.Ok() || len(dryRun.Violations) != 1 || !IsFilterFailure(dryRun.TestError) {
+		t.Errorf("expected order to fail validation and test: %s", dryRun)
+	}
+}
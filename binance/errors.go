@@ -0,0 +1,195 @@
+// The MIT License (MIT)
+//
+// Copyright (c) 2018 Cranky Kernel
+//
+// Permission is hereby granted, free of charge, to any person
+// obtaining a copy of this software and associated documentation
+// files (the "Software"), to deal in the Software without
+// restriction, including without limitation the rights to use, copy,
+// modify, merge, publish, distribute, sublicense, and/or sell copies
+// of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be
+// included in all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND,
+// EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF
+// MERCHANTABILITY, FITNESS FOR A PARTICULAR PURPOSE AND
+// NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT HOLDERS
+// BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER IN AN
+// ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN
+// CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package binance
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"strings"
+)
+
+// Binance API error codes.
+const (
+	ErrorCodeUnknown             = -1000
+	ErrorCodeDisconnected        = -1001
+	ErrorCodeUnauthorized        = -1002
+	ErrorCodeTooManyRequests     = -1003
+	ErrorCodeUnexpectedResponse  = -1006
+	ErrorCodeTimeout             = -1007
+	ErrorCodeServerBusy          = -1008
+	ErrorCodeInvalidMessage      = -1013
+	ErrorCodeTooManyOrders       = -1015
+	ErrorCodeServiceShuttingDown = -1016
+	ErrorCodeInvalidTimestamp    = -1021
+	ErrorCodeInvalidSignature    = -1022
+	ErrorCodeBadPrecision        = -1111
+	ErrorCodeNewOrderRejected    = -2010
+	ErrorCodeCancelRejected      = -2011
+	ErrorCodeNoSuchOrder         = -2013
+	ErrorCodeBadApiKeyFormat     = -2014
+	ErrorCodeRejectedMbxKey      = -2015
+)
+
+type ErrorCategory string
+
+const (
+	ErrorCategoryUnknown           ErrorCategory = "UNKNOWN"
+	ErrorCategoryAuth              ErrorCategory = "AUTH"
+	ErrorCategoryTimestamp         ErrorCategory = "TIMESTAMP"
+	ErrorCategoryFilter            ErrorCategory = "FILTER"
+	ErrorCategoryInsufficientFunds ErrorCategory = "INSUFFICIENT_FUNDS"
+	ErrorCategoryUnknownOrder      ErrorCategory = "UNKNOWN_ORDER"
+	ErrorCategoryRateLimit         ErrorCategory = "RATE_LIMIT"
+	ErrorCategoryServer            ErrorCategory = "SERVER"
+)
+
+// RestApiError is an error response from the REST API. Code and Message are
+// parsed from the {"code":..,"msg":..} payload if present.
+type RestApiError struct {
+	StatusCode int
+	Body       []byte
+	Code       int64
+	Message    string
+	Category   ErrorCategory
+}
+
+func NewRestApiErrorFromResponse(r *http.Response) *RestApiError {
+	body, _ := ioutil.ReadAll(r.Body)
+	return newRestApiError(r.StatusCode, body)
+}
+
+func newRestApiError(statusCode int, body []byte) *RestApiError {
+	apiError := &RestApiError{
+		StatusCode: statusCode,
+		Body:       body,
+	}
+	var payload struct {
+		Code    int64  `json:"code"`
+		Message string `json:"msg"`
+	}
+	if err := json.Unmarshal(body, &payload); err == nil {
+		apiError.Code = payload.Code
+		apiError.Message = payload.Message
+	}
+	apiError.Category = classifyError(statusCode, apiError.Code, apiError.Message)
+	return apiError
+}
+
+func (e *RestApiError) Error() string {
+	if e.Code != 0 {
+		return fmt.Sprintf("%s (code %d)", e.Message, e.Code)
+	}
+	if len(e.Body) > 0 {
+		return string(e.Body)
+	}
+	return fmt.Sprintf("%d %s", e.StatusCode, http.StatusText(e.StatusCode))
+}
+
+func classifyError(statusCode int, code int64, message string) ErrorCategory {
+	switch code {
+	case ErrorCodeUnauthorized, ErrorCodeInvalidSignature,
+		ErrorCodeBadApiKeyFormat, ErrorCodeRejectedMbxKey:
+		return ErrorCategoryAuth
+	case ErrorCodeInvalidTimestamp:
+		return ErrorCategoryTimestamp
+	case ErrorCodeInvalidMessage, ErrorCodeBadPrecision:
+		return ErrorCategoryFilter
+	case ErrorCodeNoSuchOrder:
+		return ErrorCategoryUnknownOrder
+	case ErrorCodeTooManyRequests, ErrorCodeTooManyOrders:
+		return ErrorCategoryRateLimit
+	case ErrorCodeUnknown, ErrorCodeDisconnected, ErrorCodeUnexpectedResponse,
+		ErrorCodeTimeout, ErrorCodeServerBusy, ErrorCodeServiceShuttingDown:
+		return ErrorCategoryServer
+	case ErrorCodeNewOrderRejected, ErrorCodeCancelRejected:
+		// These codes cover several reasons that are only distinguished by
+		// the message.
+		lower := strings.ToLower(message)
+		switch {
+		case strings.Contains(lower, "insufficient balance"):
+			return ErrorCategoryInsufficientFunds
+		case strings.Contains(lower, "filter failure"):
+			return ErrorCategoryFilter
+		case strings.Contains(lower, "unknown order"),
+			strings.Contains(lower, "does not exist"):
+			return ErrorCategoryUnknownOrder
+		}
+	}
+
+	switch {
+	case statusCode == http.StatusTooManyRequests || statusCode == 418:
+		return ErrorCategoryRateLimit
+	case statusCode == http.StatusUnauthorized:
+		return ErrorCategoryAuth
+	case statusCode >= 500:
+		return ErrorCategoryServer
+	}
+	return ErrorCategoryUnknown
+}
+
+// ErrorCategoryOf returns the category of an error returned by the client.
+// A *RateLimitError is categorized as a rate limit error and an
+// *OrderValidationError as a filter failure.
+func ErrorCategoryOf(err error) ErrorCategory {
+	switch err := err.(type) {
+	case *RestApiError:
+		return err.Category
+	case *RateLimitError:
+		return ErrorCategoryRateLimit
+	case *OrderValidationError:
+		return ErrorCategoryFilter
+	}
+	return ErrorCategoryUnknown
+}
+
+func IsAuthError(err error) bool {
+	return ErrorCategoryOf(err) == ErrorCategoryAuth
+}
+
+func IsTimestampError(err error) bool {
+	return ErrorCategoryOf(err) == ErrorCategoryTimestamp
+}
+
+func IsFilterFailure(err error) bool {
+	return ErrorCategoryOf(err) == ErrorCategoryFilter
+}
+
+func IsInsufficientFunds(err error) bool {
+	return ErrorCategoryOf(err) == ErrorCategoryInsufficientFunds
+}
+
+func IsUnknownOrder(err error) bool {
+	return ErrorCategoryOf(err) == ErrorCategoryUnknownOrder
+}
+
+func IsRateLimited(err error) bool {
+	return ErrorCategoryOf(err) == ErrorCategoryRateLimit
+}
+
+func IsServerError(err error) bool {
+	return ErrorCategoryOf(err) == ErrorCategoryServer
+}
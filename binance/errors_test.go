@@ -0,0 +1,43 @@
+package binance
+
+import (
+	"net/http"
+	"testing"
+)
+
+func TestRestApiErrorCategory(t *testing.T) {
+	tests := []struct {
+		statusCode int
+		body       string
+		category   ErrorCategory
+	}{
+		{400, `{"code":-2010,"msg":"Account has insufficient balance for requested action."}`, ErrorCategoryInsufficientFunds},
+		{400, `{"code":-1013,"msg":"Filter failure: LOT_SIZE"}`, ErrorCategoryFilter},
+		{400, `{"code":-2010,"msg":"Filter failure: MIN_NOTIONAL"}`, ErrorCategoryFilter},
+		{400, `{"code":-2011,"msg":"Unknown order sent."}`, ErrorCategoryUnknownOrder},
+		{400, `{"code":-2013,"msg":"Order does not exist."}`, ErrorCategoryUnknownOrder},
+		{400, `{"code":-1021,"msg":"Timestamp for this request is outside of the recvWindow."}`, ErrorCategoryTimestamp},
+		{401, `{"code":-2015,"msg":"Invalid API-key, IP, or permissions for action."}`, ErrorCategoryAuth},
+		{429, `{"code":-1003,"msg":"Too many requests."}`, ErrorCategoryRateLimit},
+		{418, ``, ErrorCategoryRateLimit},
+		{502, `<html>Bad Gateway</html>`, ErrorCategoryServer},
+		{400, `{"code":-1100,"msg":"Illegal characters found in parameter 'symbol'."}`, ErrorCategoryUnknown},
+	}
+	for _, test := range tests {
+		err := newRestApiError(test.statusCode, []byte(test.body))
+		if err.Category != test.category {
+			t.Errorf("expected %s for %s, got %s", test.category, test.body, err.Category)
+		}
+	}
+
+	err := newRestApiError(http.StatusBadRequest, []byte(`{"code":-2011,"msg":"Unknown order sent."}`))
+	if !IsUnknownOrder(err) {
+		t.Errorf("expected IsUnknownOrder")
+	}
+	if err.Error() != "Unknown order sent. (code -2011)" {
+		t.Errorf("unexpected error string: %s", err.Error())
+	}
+	if !IsRateLimited(&RateLimitError{}) {
+		t.Errorf("expected RateLimitError to be rate limited")
+	}
+}
@@ -0,0 +1,265 @@
+// The MIT License (MIT)
+//
+// Copyright (c) 2018 Cranky Kernel
+//
+// Permission is hereby granted, free of charge, to any person
+// obtaining a copy of this software and associated documentation
+// files (the "Software"), to deal in the Software without
+// restriction, including without limitation the rights to use, copy,
+// modify, merge, publish, distribute, sublicense, and/or sell copies
+// of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be
+// included in all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND,
+// EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF
+// MERCHANTABILITY, FITNESS FOR A PARTICULAR PURPOSE AND
+// NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT HOLDERS
+// BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER IN AN
+// ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN
+// CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package binance
+
+import (
+	"fmt"
+	"strconv"
+	"time"
+
+	"gitlab.com/crankykernel/cryptotrader/core"
+	"gitlab.com/crankykernel/cryptotrader/decimal"
+	"gitlab.com/crankykernel/cryptotrader/util"
+)
+
+// Exchange adapts a RestClient to the core.Exchange interface.
+type Exchange struct {
+	client *RestClient
+}
+
+func NewExchange(client *RestClient) *Exchange {
+	return &Exchange{
+		client: client,
+	}
+}
+
+func (e *Exchange) Name() string {
+	return "Binance"
+}
+
+func (e *Exchange) GetSymbols() ([]core.Symbol, error) {
+	exchangeInfo, err := e.client.GetExchangeInfo()
+	if err != nil {
+		return nil, err
+	}
+	symbols := []core.Symbol{}
+	for _, info := range exchangeInfo.Symbols {
+		symbols = append(symbols, core.Symbol{
+			Name:       info.Symbol,
+			BaseAsset:  info.BaseAsset,
+			QuoteAsset: info.QuoteAsset,
+		})
+	}
+	return symbols, nil
+}
+
+func (e *Exchange) GetTickers(symbols ...string) ([]core.Ticker, error) {
+	response, err := e.client.GetAll24hrTicker()
+	if err != nil {
+		return nil, err
+	}
+	now := time.Now()
+	tickers := []core.Ticker{}
+	for _, ticker := range response {
+		if len(symbols) > 0 && !containsString(symbols, ticker.Symbol) {
+			continue
+		}
+		tickers = append(tickers, core.Ticker{
+			Exchange:  e.Name(),
+			Symbol:    ticker.Symbol,
+			Timestamp: now,
+			Last:      ticker.LastPrice.Float64(),
+			Bid:       ticker.BidPrice.Float64(),
+			Ask:       ticker.AskPrice.Float64(),
+			Volume:    ticker.Volume.Float64(),
+		})
+	}
+	return tickers, nil
+}
+
+func (e *Exchange) GetBalances() ([]core.Balance, error) {
+	account, err := e.client.GetAccount()
+	if err != nil {
+		return nil, err
+	}
+	balances := []core.Balance{}
+	for _, balance := range account.Balances {
+		if balance.Free.IsZero() && balance.Locked.IsZero() {
+			continue
+		}
+		balances = append(balances, core.Balance{
+			Asset:  balance.Asset,
+			Free:   balance.Free.Float64(),
+			Locked: balance.Locked.Float64(),
+		})
+	}
+	return balances, nil
+}
+
+func (e *Exchange) GetOpenOrders(symbol string) ([]core.Order, error) {
+	response, err := e.client.GetOpenOrders(symbol)
+	if err != nil {
+		return nil, err
+	}
+	orders := []core.Order{}
+	for _, order := range response {
+		orders = append(orders, e.toCoreOrder(order))
+	}
+	return orders, nil
+}
+
+func (e *Exchange) PlaceOrder(request core.OrderRequest) (*core.Order, error) {
+	order := OrderParameters{
+		Symbol:           request.Symbol,
+		Side:             OrderSide(request.Side),
+		Type:             OrderType(request.Type),
+		Quantity:         decimal.NewFromFloat64(request.Quantity),
+		Price:            decimal.NewFromFloat64(request.Price),
+		NewClientOrderId: request.ClientOrderId,
+		NewOrderRespType: OrderResponseTypeResult,
+	}
+
+	response, err := e.client.PostOrder(order)
+	if err != nil {
+		return nil, err
+	}
+	status := response.Status
+	if status == "" {
+		status = OrderStatusNew
+	}
+
+	return &core.Order{
+		Exchange:       e.Name(),
+		Symbol:         response.Symbol,
+		OrderId:        strconv.FormatInt(response.OrderId, 10),
+		ClientOrderId:  response.ClientOrderId,
+		Side:           request.Side,
+		Type:           request.Type,
+		Status:         string(status),
+		Price:          request.Price,
+		Quantity:       request.Quantity,
+		FilledQuantity: response.ExecutedQuantity.Float64(),
+		Timestamp:      util.MillisToTime(response.TransactionTimeMillis),
+	}, nil
+}
+
+func (e *Exchange) CancelOrder(symbol string, orderId string) error {
+	id, err := strconv.ParseInt(orderId, 10, 64)
+	if err != nil {
+		return fmt.Errorf("invalid order id: %s", orderId)
+	}
+	_, err = e.client.CancelOrder(symbol, id)
+	return err
+}
+
+// GetTrades returns the full trade history for a symbol, paging through
+// as many requests as needed. Binance requires a symbol.
+func (e *Exchange) GetTrades(symbol string) ([]core.Trade, error) {
+	if symbol == "" {
+		return nil, fmt.Errorf("binance: a symbol is required")
+	}
+	trades := []core.Trade{}
+	iterator := e.client.IterateMyTrades([]string{symbol}, time.Time{}, time.Time{})
+	for iterator.Next() {
+		trade := iterator.Trade()
+		side := core.OrderSideSell
+		if trade.IsBuyer {
+			side = core.OrderSideBuy
+		}
+		trades = append(trades, core.Trade{
+			Exchange:  e.Name(),
+			Symbol:    symbol,
+			TradeId:   strconv.FormatInt(trade.ID, 10),
+			OrderId:   strconv.FormatInt(trade.OrderID, 10),
+			Side:      side,
+			Price:     trade.Price.Float64(),
+			Quantity:  trade.Quantity.Float64(),
+			Fee:       trade.Commission.Float64(),
+			FeeAsset:  trade.CommissionAsset,
+			Timestamp: util.MillisToTime(trade.TimeMillis),
+		})
+	}
+	if err := iterator.Err(); err != nil {
+		return nil, err
+	}
+	return trades, nil
+}
+
+func (e *Exchange) GetTransfers(asset string) ([]core.Transfer, error) {
+	deposits, err := e.client.GetDepositHistory(asset)
+	if err != nil {
+		return nil, err
+	}
+	withdrawals, err := e.client.GetWithdrawHistory(asset)
+	if err != nil {
+		return nil, err
+	}
+
+	transfers := []core.Transfer{}
+	for _, deposit := range deposits {
+		transfers = append(transfers, core.Transfer{
+			Exchange:   e.Name(),
+			Asset:      deposit.Coin,
+			TransferId: deposit.Id,
+			Type:       core.TransferTypeDeposit,
+			Status:     strconv.FormatInt(deposit.Status, 10),
+			Amount:     deposit.Amount.Float64(),
+			Address:    deposit.Address,
+			TxId:       deposit.TxId,
+			Timestamp:  util.MillisToTime(deposit.InsertTimeMillis),
+		})
+	}
+	for _, withdrawal := range withdrawals {
+		timestamp, _ := time.Parse("2006-01-02 15:04:05", withdrawal.ApplyTime)
+		transfers = append(transfers, core.Transfer{
+			Exchange:   e.Name(),
+			Asset:      withdrawal.Coin,
+			TransferId: withdrawal.Id,
+			Type:       core.TransferTypeWithdrawal,
+			Status:     strconv.FormatInt(withdrawal.Status, 10),
+			Amount:     withdrawal.Amount.Float64(),
+			Fee:        withdrawal.TransactionFee.Float64(),
+			Address:    withdrawal.Address,
+			TxId:       withdrawal.TxId,
+			Timestamp:  timestamp,
+		})
+	}
+	return transfers, nil
+}
+
+func (e *Exchange) toCoreOrder(order QueryOrderResponse) core.Order {
+	return core.Order{
+		Exchange:       e.Name(),
+		Symbol:         order.Symbol,
+		OrderId:        strconv.FormatInt(order.OrderId, 10),
+		ClientOrderId:  order.ClientOrderId,
+		Side:           core.OrderSide(order.Side),
+		Type:           core.OrderType(order.Type),
+		Status:         string(order.Status),
+		Price:          order.Price.Float64(),
+		Quantity:       order.OrigQty.Float64(),
+		FilledQuantity: order.ExecutedQty.Float64(),
+		Timestamp:      util.MillisToTime(order.TimeMillis),
+	}
+}
+
+func containsString(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
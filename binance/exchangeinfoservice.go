@@ -24,26 +24,50 @@
 
 package binance
 
-import "fmt"
+import (
+	"fmt"
+	"gitlab.com/crankykernel/cryptotrader/decimal"
+)
 
 type SymbolInfo struct {
-	TickSize float64
-	StepSize float64
-	MinNotional float64
+	TickSize    decimal.Decimal
+	StepSize    decimal.Decimal
+	MinNotional decimal.Decimal
+}
+
+// RoundPrice rounds a price to the nearest multiple of the tick size. The
+// tick size is normalized so the price is not padded with trailing zeros.
+func (i SymbolInfo) RoundPrice(price decimal.Decimal) decimal.Decimal {
+	return price.RoundToStep(i.TickSize.Normalize())
+}
+
+// RoundQuantity rounds a quantity down to a multiple of the step size so
+// the quantity is never more than requested.
+func (i SymbolInfo) RoundQuantity(quantity decimal.Decimal) decimal.Decimal {
+	return quantity.FloorToStep(i.StepSize.Normalize())
 }
 
 type ExchangeInfoService struct {
 	Symbols map[string]SymbolInfo
+
+	// Validates orders against the filters from the last update.
+	Validator *OrderValidator
+
+	client *RestClient
 }
 
-func NewExchangeInfoService() *ExchangeInfoService {
+// NewExchangeInfoService returns a service that fetches the exchange info
+// with an anonymous client configured by options, so the REST URL, testnet
+// and HTTP client settings are used.
+func NewExchangeInfoService(options ...ClientOption) *ExchangeInfoService {
 	return &ExchangeInfoService{
 		Symbols: make(map[string]SymbolInfo),
+		client:  NewAnonymousClient(options...),
 	}
 }
 
 func (s *ExchangeInfoService) Update() error {
-	exchangeInfo, err := GetExchangeInfo()
+	exchangeInfo, err := s.client.GetExchangeInfo()
 	if err != nil {
 		return err
 	}
@@ -61,6 +85,7 @@ func (s *ExchangeInfoService) Update() error {
 		}
 		s.Symbols[symbol.Symbol] = symbolInfo
 	}
+	s.Validator = NewOrderValidator(exchangeInfo)
 	return nil
 }
 
@@ -74,28 +99,28 @@ func (s *ExchangeInfoService) GetSymbol(symbol string) (info SymbolInfo, err err
 }
 
 // GetTickSize returns the tick size for the requested symbol.
-func (s *ExchangeInfoService) GetTickSize(symbol string) (float64, error) {
+func (s *ExchangeInfoService) GetTickSize(symbol string) (decimal.Decimal, error) {
 	symbolInfo, ok := s.Symbols[symbol]
 	if !ok {
-		return 0, fmt.Errorf("symbol not found")
+		return decimal.Zero, fmt.Errorf("symbol not found")
 	}
 	return symbolInfo.TickSize, nil
 }
 
 // GetMinNotional returns the minimum notional value for the requested symbol.
-func (s *ExchangeInfoService) GetMinNotional(symbol string) (float64, error) {
+func (s *ExchangeInfoService) GetMinNotional(symbol string) (decimal.Decimal, error) {
 	symbolInfo, ok := s.Symbols[symbol]
 	if !ok {
-		return 0, fmt.Errorf("symbol not found")
+		return decimal.Zero, fmt.Errorf("symbol not found")
 	}
 	return symbolInfo.MinNotional, nil
 }
 
 // GetStepSize returns the step size for the requested symbol.
-func (s *ExchangeInfoService) GetStepSize(symbol string) (float64, error) {
+func (s *ExchangeInfoService) GetStepSize(symbol string) (decimal.Decimal, error) {
 	symbolInfo, ok := s.Symbols[symbol]
 	if !ok {
-		return 0, fmt.Errorf("symbol not found")
+		return decimal.Zero, fmt.Errorf("symbol not found")
 	}
 	return symbolInfo.StepSize, nil
 }
\ No newline at end of file
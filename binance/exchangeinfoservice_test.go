@@ -0,0 +1,31 @@
+package binance
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"gitlab.com/crankykernel/cryptotrader/decimal"
+)
+
+func TestExchangeInfoServiceUpdate(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(testExchangeInfo))
+	}))
+	defer server.Close()
+
+	service := NewExchangeInfoService(WithRestUrl(server.URL), WithRateLimiter(nil))
+	if err := service.Update(); err != nil {
+		t.Fatal(err)
+	}
+	tickSize, err := service.GetTickSize("ETHBTC")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !tickSize.Equal(decimal.MustParse("0.000001")) {
+		t.Errorf("unexpected tick size: %s", tickSize)
+	}
+	if service.Validator == nil {
+		t.Errorf("expected a validator")
+	}
+}
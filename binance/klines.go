@@ -0,0 +1,244 @@
+// The MIT License (MIT)
+//
+// Copyright (c) 2018 Cranky Kernel
+//
+// Permission is hereby granted, free of charge, to any person
+// obtaining a copy of this software and associated documentation
+// files (the "Software"), to deal in the Software without
+// restriction, including without limitation the rights to use, copy,
+// modify, merge, publish, distribute, sublicense, and/or sell copies
+// of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be
+// included in all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND,
+// EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF
+// MERCHANTABILITY, FITNESS FOR A PARTICULAR PURPOSE AND
+// NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT HOLDERS
+// BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER IN AN
+// ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN
+// CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package binance
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"gitlab.com/crankykernel/cryptotrader/decimal"
+	"gitlab.com/crankykernel/cryptotrader/util"
+)
+
+// The maximum number of klines returned by a single klines request.
+const KLINES_MAX_LIMIT = 1000
+
+type KlineInterval string
+
+const (
+	KlineInterval1m  KlineInterval = "1m"
+	KlineInterval3m  KlineInterval = "3m"
+	KlineInterval5m  KlineInterval = "5m"
+	KlineInterval15m KlineInterval = "15m"
+	KlineInterval30m KlineInterval = "30m"
+	KlineInterval1h  KlineInterval = "1h"
+	KlineInterval2h  KlineInterval = "2h"
+	KlineInterval4h  KlineInterval = "4h"
+	KlineInterval6h  KlineInterval = "6h"
+	KlineInterval8h  KlineInterval = "8h"
+	KlineInterval12h KlineInterval = "12h"
+	KlineInterval1d  KlineInterval = "1d"
+	KlineInterval3d  KlineInterval = "3d"
+	KlineInterval1w  KlineInterval = "1w"
+	KlineInterval1M  KlineInterval = "1M"
+)
+
+var klineIntervalDurations = map[KlineInterval]time.Duration{
+	KlineInterval1m:  time.Minute,
+	KlineInterval3m:  3 * time.Minute,
+	KlineInterval5m:  5 * time.Minute,
+	KlineInterval15m: 15 * time.Minute,
+	KlineInterval30m: 30 * time.Minute,
+	KlineInterval1h:  time.Hour,
+	KlineInterval2h:  2 * time.Hour,
+	KlineInterval4h:  4 * time.Hour,
+	KlineInterval6h:  6 * time.Hour,
+	KlineInterval8h:  8 * time.Hour,
+	KlineInterval12h: 12 * time.Hour,
+	KlineInterval1d:  24 * time.Hour,
+	KlineInterval3d:  3 * 24 * time.Hour,
+	KlineInterval1w:  7 * 24 * time.Hour,
+	KlineInterval1M:  31 * 24 * time.Hour,
+}
+
+// ParseKlineInterval checks that an interval is one supported by Binance.
+func ParseKlineInterval(value string) (KlineInterval, error) {
+	interval := KlineInterval(value)
+	if _, ok := klineIntervalDurations[interval]; !ok {
+		return "", fmt.Errorf("invalid kline interval: %s", value)
+	}
+	return interval, nil
+}
+
+// Duration returns the length of the interval. Months are taken to be the
+// longest month, 31 days.
+func (i KlineInterval) Duration() time.Duration {
+	return klineIntervalDurations[i]
+}
+
+// A kline (candlestick). Binance sends klines as arrays; they are encoded to
+// JSON as objects.
+type Kline struct {
+	OpenTimeMillis      int64           `json:"openTime"`
+	Open                decimal.Decimal `json:"open"`
+	High                decimal.Decimal `json:"high"`
+	Low                 decimal.Decimal `json:"low"`
+	Close               decimal.Decimal `json:"close"`
+	Volume              decimal.Decimal `json:"volume"`
+	CloseTimeMillis     int64           `json:"closeTime"`
+	QuoteVolume         decimal.Decimal `json:"quoteVolume"`
+	TradeCount          int64           `json:"trades"`
+	TakerBuyBaseVolume  decimal.Decimal `json:"takerBuyBaseVolume"`
+	TakerBuyQuoteVolume decimal.Decimal `json:"takerBuyQuoteVolume"`
+}
+
+// UnmarshalJSON decodes a kline from either the array form returned by the
+// REST API or the object form it is encoded to.
+func (k *Kline) UnmarshalJSON(data []byte) error {
+	if len(data) > 0 && data[0] == '{' {
+		type kline Kline
+		return json.Unmarshal(data, (*kline)(k))
+	}
+	var fields []json.RawMessage
+	if err := json.Unmarshal(data, &fields); err != nil {
+		return err
+	}
+	if len(fields) < 11 {
+		return fmt.Errorf("expected 11 kline fields, got %d", len(fields))
+	}
+	values := []interface{}{
+		&k.OpenTimeMillis,
+		&k.Open,
+		&k.High,
+		&k.Low,
+		&k.Close,
+		&k.Volume,
+		&k.CloseTimeMillis,
+		&k.QuoteVolume,
+		&k.TradeCount,
+		&k.TakerBuyBaseVolume,
+		&k.TakerBuyQuoteVolume,
+	}
+	for i, value := range values {
+		if err := json.Unmarshal(fields[i], value); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (k *Kline) OpenTime() time.Time {
+	return util.MillisToTime(k.OpenTimeMillis)
+}
+
+func (k *Kline) CloseTime() time.Time {
+	return util.MillisToTime(k.CloseTimeMillis)
+}
+
+// KlinesParameters selects the klines returned by GetKlines. Zero times and
+// limit are not sent.
+type KlinesParameters struct {
+	Symbol    string
+	Interval  KlineInterval
+	StartTime time.Time
+	EndTime   time.Time
+	Limit     int64
+}
+
+// GetKlines returns a single page of klines, oldest first.
+func (c *RestClient) GetKlines(query KlinesParameters) ([]Kline, error) {
+	params := map[string]interface{}{
+		"symbol":   query.Symbol,
+		"interval": query.Interval,
+	}
+	if !query.StartTime.IsZero() {
+		params["startTime"] = util.TimeToMillis(query.StartTime)
+	}
+	if !query.EndTime.IsZero() {
+		params["endTime"] = util.TimeToMillis(query.EndTime)
+	}
+	if query.Limit > 0 {
+		params["limit"] = query.Limit
+	}
+
+	httpResponse, err := c.Get("/api/v3/klines", params)
+	if err != nil {
+		return nil, err
+	}
+	defer httpResponse.Body.Close()
+	if httpResponse.StatusCode != http.StatusOK {
+		return nil, NewRestApiErrorFromResponse(httpResponse)
+	}
+	var klines []Kline
+	if err := json.NewDecoder(httpResponse.Body).Decode(&klines); err != nil {
+		return nil, err
+	}
+	return klines, nil
+}
+
+// DownloadKlines fetches the klines opening between start and end, making
+// as many requests as needed, and passes each page to fn as it is received
+// so long downloads can be written out as they progress. A zero start
+// downloads from the first kline and a zero end up to the last closed
+// kline. Klines that have not closed by the server time are never passed to
+// fn as they may still change. Requests are throttled by the rate limiter
+// of the client. If fn returns an error the download is stopped and the
+// error returned.
+func (c *RestClient) DownloadKlines(symbol string, interval KlineInterval, start time.Time, end time.Time, fn func([]Kline) error) error {
+	query := KlinesParameters{
+		Symbol:    symbol,
+		Interval:  interval,
+		StartTime: start,
+		EndTime:   end,
+		Limit:     KLINES_MAX_LIMIT,
+	}
+	if start.IsZero() {
+		query.StartTime = util.MillisToTime(0)
+	}
+	serverTime, err := c.GetServerTime()
+	if err != nil {
+		return err
+	}
+	serverTimeMillis := util.TimeToMillis(serverTime)
+	for {
+		klines, err := c.GetKlines(query)
+		if err != nil {
+			return err
+		}
+		done := int64(len(klines)) < query.Limit
+		for i, kline := range klines {
+			if kline.CloseTimeMillis >= serverTimeMillis {
+				klines = klines[:i]
+				done = true
+				break
+			}
+		}
+		if len(klines) == 0 {
+			return nil
+		}
+		if err := fn(klines); err != nil {
+			return err
+		}
+		if done {
+			return nil
+		}
+		query.StartTime = klines[len(klines)-1].CloseTime().Add(time.Millisecond)
+		if !end.IsZero() && query.StartTime.After(end) {
+			return nil
+		}
+	}
+}
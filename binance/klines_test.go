@@ -0,0 +1,106 @@
+package binance
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+	"time"
+
+	"gitlab.com/crankykernel/cryptotrader/decimal"
+)
+
+func TestKlineUnmarshal(t *testing.T) {
+	buf := `[1499040000000,"0.01634790","0.80000000","0.01575800","0.01577100","148976.11427815",1499644799999,"2434.19055334",308,"1756.87402397","28.46694368","0"]`
+	var kline Kline
+	if err := json.Unmarshal([]byte(buf), &kline); err != nil {
+		t.Fatal(err)
+	}
+	if kline.OpenTimeMillis != 1499040000000 || kline.CloseTimeMillis != 1499644799999 ||
+		kline.TradeCount != 308 || !kline.High.Equal(decimal.MustParse("0.8")) ||
+		!kline.TakerBuyQuoteVolume.Equal(decimal.MustParse("28.46694368")) {
+		t.Errorf("unexpected kline: %+v", kline)
+	}
+
+	// The object form it is encoded to can be decoded again.
+	encoded, err := json.Marshal(kline)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var decoded Kline
+	if err := json.Unmarshal(encoded, &decoded); err != nil {
+		t.Fatal(err)
+	}
+	if decoded.OpenTimeMillis != kline.OpenTimeMillis || !decoded.Close.Equal(kline.Close) {
+		t.Errorf("round trip failed: %s", encoded)
+	}
+}
+
+func TestDownloadKlines(t *testing.T) {
+	// 2500 closed one minute klines followed by one in progress.
+	first := time.Date(2018, 5, 1, 0, 0, 0, 0, time.UTC).UnixNano() / int64(time.Millisecond)
+	minute := int64(60 * 1000)
+	last := first + 2500*minute
+
+	requests := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/api/v3/time" {
+			fmt.Fprintf(w, `{"serverTime":%d}`, last+1)
+			return
+		}
+		requests++
+		query := r.URL.Query()
+		start, _ := strconv.ParseInt(query.Get("startTime"), 10, 64)
+		end := last
+		if value := query.Get("endTime"); value != "" {
+			end, _ = strconv.ParseInt(value, 10, 64)
+		}
+		limit, _ := strconv.Atoi(query.Get("limit"))
+		klines := [][]interface{}{}
+		for open := first; open <= end && len(klines) < limit; open += minute {
+			if open >= start {
+				klines = append(klines, []interface{}{open, "1", "1", "1", "1", "1",
+					open + minute - 1, "1", 1, "1", "1", "0"})
+			}
+		}
+		json.NewEncoder(w).Encode(klines)
+	}))
+	defer server.Close()
+
+	client := NewAnonymousClient(WithRestUrl(server.URL), WithRateLimiter(nil))
+
+	count := 0
+	err := client.DownloadKlines("ETHBTC", KlineInterval1m, time.Time{}, time.Time{},
+		func(klines []Kline) error {
+			for _, kline := range klines {
+				if kline.OpenTimeMillis != first+int64(count)*minute {
+					t.Fatalf("unexpected kline %d open time: %d", count, kline.OpenTimeMillis)
+				}
+				count++
+			}
+			return nil
+		})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if count != 2500 || requests != 3 {
+		t.Errorf("expected 2500 klines in 3 requests, got %d in %d", count, requests)
+	}
+
+	count = 0
+	start := time.Unix(0, (first+100*minute)*int64(time.Millisecond))
+	end := time.Unix(0, (first+1099*minute)*int64(time.Millisecond))
+	err = client.DownloadKlines("ETHBTC", KlineInterval1m, start, end,
+		func(klines []Kline) error {
+			count += len(klines)
+			return nil
+		})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if count != 1000 {
+		t.Errorf("expected 1000 klines, got %d", count)
+	}
+}
@@ -0,0 +1,299 @@
+// The MIT License (MIT)
+//
+// Copyright (c) 2018 Cranky Kernel
+//
+// Permission is hereby granted, free of charge, to any person
+// obtaining a copy of this software and associated documentation
+// files (the "Software"), to deal in the Software without
+// restriction, including without limitation the rights to use, copy,
+// modify, merge, publish, distribute, sublicense, and/or sell copies
+// of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be
+// included in all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND,
+// EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF
+// MERCHANTABILITY, FITNESS FOR A PARTICULAR PURPOSE AND
+// NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT HOLDERS
+// BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER IN AN
+// ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN
+// CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package binance
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"gitlab.com/crankykernel/cryptotrader/util"
+)
+
+type KlineStreamEventType string
+
+const (
+	// An update to the candle in progress.
+	KlineStreamUpdate KlineStreamEventType = "UPDATE"
+
+	// A candle has closed and is final. Closed candles are sent in order
+	// and without gaps, those missed while disconnected are fetched over
+	// REST and have Backfilled set.
+	KlineStreamClosed KlineStreamEventType = "CLOSED"
+
+	// The stream connection was lost, Err is the cause.
+	KlineStreamDisconnected KlineStreamEventType = "DISCONNECTED"
+
+	// The stream has reconnected. Closed candles missed while disconnected
+	// follow.
+	KlineStreamReconnected KlineStreamEventType = "RECONNECTED"
+
+	// Fetching missed candles failed, Err is the cause. The fetch is
+	// retried with backoff and newer candles are held back until it
+	// succeeds.
+	KlineStreamError KlineStreamEventType = "ERROR"
+)
+
+type KlineStreamEvent struct {
+	Type       KlineStreamEventType
+	Symbol     string
+	Interval   KlineInterval
+	Kline      Kline
+	Backfilled bool
+	Err        error
+}
+
+// KlineStream follows the <symbol>@kline_<interval> stream over a
+// ManagedStream, so the connection is kept alive and re-established, and
+// turns it into candle updates and candle closed events.
+type KlineStream struct {
+	// The delay before retrying a failed backfill doubles with each
+	// failure, from MinBackoff up to MaxBackoff.
+	MinBackoff time.Duration
+	MaxBackoff time.Duration
+
+	symbol   string
+	interval KlineInterval
+	options  []ClientOption
+
+	// The last closed candle sent, used to find missed candles.
+	lastClosed *Kline
+}
+
+func NewKlineStream(symbol string, interval KlineInterval, options ...ClientOption) *KlineStream {
+	return &KlineStream{
+		MinBackoff: DEFAULT_STREAM_MIN_BACKOFF,
+		MaxBackoff: DEFAULT_STREAM_MAX_BACKOFF,
+		symbol:     strings.ToUpper(symbol),
+		interval:   interval,
+		options:    options,
+	}
+}
+
+// klineBackfill is the result of fetching the missed candles up to
+// endMillis in the background, with the live closed candle that revealed
+// the gap, if any, to send after them.
+type klineBackfill struct {
+	klines    []Kline
+	err       error
+	endMillis int64
+	then      *Kline
+}
+
+// Run follows the stream, sending events to channel until ctx is done, and
+// returns ctx.Err().
+func (s *KlineStream) Run(ctx context.Context, channel chan<- KlineStreamEvent) error {
+	name := fmt.Sprintf("%s@kline_%s", strings.ToLower(s.symbol), s.interval)
+	stream := NewManagedStream([]string{name}, s.options...)
+	stream.Single = true
+	client := NewAnonymousClient(s.options...).WithContext(ctx)
+
+	send := func(event KlineStreamEvent) error {
+		event.Symbol = s.symbol
+		event.Interval = s.interval
+		select {
+		case channel <- event:
+			return nil
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+
+	events := make(chan ManagedStreamEvent)
+	result := make(chan error, 1)
+	go func() {
+		result <- stream.Run(ctx, events)
+	}()
+
+	// Missed candles are fetched in the background so the stream keeps
+	// being read, a slow backfill would otherwise hit the read timeout.
+	// Messages received meanwhile are queued until the backfill is sent.
+	// A failed backfill is retried after delay, so nothing newer is sent
+	// until the gap is filled.
+	var backfilling chan klineBackfill
+	queued := [][]byte{}
+	failures := 0
+
+	backfill := func(endMillis int64, then *Kline, delay time.Duration) {
+		done := make(chan klineBackfill, 1)
+		backfilling = done
+		fromMillis := s.lastClosed.CloseTimeMillis + 1
+		go func() {
+			result := klineBackfill{endMillis: endMillis, then: then}
+			timer := time.NewTimer(delay)
+			defer timer.Stop()
+			select {
+			case <-timer.C:
+				result.klines, result.err = s.fetchMissed(client, fromMillis, endMillis)
+			case <-ctx.Done():
+				result.err = ctx.Err()
+			}
+			done <- result
+		}()
+	}
+
+	handleMessage := func(message []byte) error {
+		// Anything that isn't a kline is ignored.
+		data, err := DecodeStreamData(name, message)
+		if err != nil {
+			return nil
+		}
+		streamKline, ok := data.(*StreamKline)
+		if !ok {
+			return nil
+		}
+		kline := streamKline.Kline.ToKline()
+
+		if !streamKline.Kline.Closed {
+			return send(KlineStreamEvent{Type: KlineStreamUpdate, Kline: kline})
+		}
+
+		// Fill in any candles missed before this one.
+		if s.lastClosed != nil && kline.OpenTimeMillis > s.lastClosed.CloseTimeMillis+1 {
+			backfill(kline.OpenTimeMillis-1, &kline, 0)
+			return nil
+		}
+		return s.sendClosed(kline, false, send)
+	}
+
+	for {
+		var event ManagedStreamEvent
+		select {
+		case event = <-events:
+		case done := <-backfilling:
+			backfilling = nil
+			if done.err != nil {
+				if ctx.Err() != nil {
+					return ctx.Err()
+				}
+				if err := send(KlineStreamEvent{Type: KlineStreamError, Err: done.err}); err != nil {
+					return err
+				}
+				backfill(done.endMillis, done.then, s.backoff(failures))
+				failures++
+				continue
+			}
+			failures = 0
+			if err := s.sendBackfill(done, send); err != nil {
+				return err
+			}
+			for len(queued) > 0 && backfilling == nil {
+				message := queued[0]
+				queued = queued[1:]
+				if err := handleMessage(message); err != nil {
+					return err
+				}
+			}
+			continue
+		case err := <-result:
+			return err
+		}
+
+		var err error
+		switch event.Type {
+		case ManagedStreamDisconnected:
+			err = send(KlineStreamEvent{Type: KlineStreamDisconnected, Err: event.Err})
+		case ManagedStreamReconnected:
+			err = send(KlineStreamEvent{Type: KlineStreamReconnected})
+			// A reconnect during a backfill is caught by the gap check
+			// when the next candle closes.
+			if err == nil && backfilling == nil && s.lastClosed != nil {
+				backfill(0, nil, 0)
+			}
+		case ManagedStreamMessage:
+			if backfilling != nil {
+				queued = append(queued, event.Message)
+			} else {
+				err = handleMessage(event.Message)
+			}
+		}
+		if err != nil {
+			return err
+		}
+	}
+}
+
+// sendClosed sends a candle closed event unless the candle has already been
+// sent.
+func (s *KlineStream) sendClosed(kline Kline, backfilled bool, send func(KlineStreamEvent) error) error {
+	if s.lastClosed != nil && kline.OpenTimeMillis <= s.lastClosed.OpenTimeMillis {
+		return nil
+	}
+	if err := send(KlineStreamEvent{
+		Type:       KlineStreamClosed,
+		Kline:      kline,
+		Backfilled: backfilled,
+	}); err != nil {
+		return err
+	}
+	s.lastClosed = &kline
+	return nil
+}
+
+// sendBackfill sends the candles of a successful backfill followed by the
+// live candle that revealed the gap.
+func (s *KlineStream) sendBackfill(done klineBackfill, send func(KlineStreamEvent) error) error {
+	for _, kline := range done.klines {
+		if err := s.sendClosed(kline, true, send); err != nil {
+			return err
+		}
+	}
+	if done.then != nil {
+		return s.sendClosed(*done.then, false, send)
+	}
+	return nil
+}
+
+// backoff returns the delay before retrying a backfill that has failed
+// failures times before.
+func (s *KlineStream) backoff(failures int) time.Duration {
+	delay := s.MinBackoff
+	for i := 0; i < failures && delay < s.MaxBackoff; i++ {
+		delay *= 2
+	}
+	if delay > s.MaxBackoff {
+		delay = s.MaxBackoff
+	}
+	return delay
+}
+
+// fetchMissed returns the closed candles opening from fromMillis up to and
+// including the candle open at endMillis. If endMillis is 0 all closed
+// candles are returned; DownloadKlines leaves out the candle in progress
+// by comparing close times to the server time.
+func (s *KlineStream) fetchMissed(client *RestClient, fromMillis int64, endMillis int64) ([]Kline, error) {
+	var end time.Time
+	if endMillis > 0 {
+		end = util.MillisToTime(endMillis)
+	}
+	klines := []Kline{}
+	err := client.DownloadKlines(s.symbol, s.interval, util.MillisToTime(fromMillis), end,
+		func(page []Kline) error {
+			klines = append(klines, page...)
+			return nil
+		})
+	return klines, err
+}
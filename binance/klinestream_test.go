@@ -0,0 +1,258 @@
+package binance
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/gorilla/websocket"
+)
+
+func TestKlineStreamBackfill(t *testing.T) {
+	first := int64(1525132800000)
+	minute := int64(60 * 1000)
+	openTime := func(i int64) int64 {
+		return first + i*minute
+	}
+	streamKline := func(i int64, closed bool) string {
+		return fmt.Sprintf(`{"e":"kline","E":%d,"s":"ETHBTC","k":{"t":%d,"T":%d,"s":"ETHBTC","i":"1m","o":"1","c":"%d","x":%v}}`,
+			openTime(i)+1, openTime(i), openTime(i)+minute-1, i, closed)
+	}
+
+	lock := sync.Mutex{}
+	lastKline := int64(4)
+	connections := 0
+	backfilled := make(chan bool, 10)
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/api/v3/time", func(w http.ResponseWriter, r *http.Request) {
+		// The last kline is in progress.
+		lock.Lock()
+		defer lock.Unlock()
+		fmt.Fprintf(w, `{"serverTime":%d}`, openTime(lastKline)+1)
+	})
+	mux.HandleFunc("/api/v3/klines", func(w http.ResponseWriter, r *http.Request) {
+		query := r.URL.Query()
+		start, _ := strconv.ParseInt(query.Get("startTime"), 10, 64)
+		end := int64(1 << 62)
+		if value := query.Get("endTime"); value != "" {
+			end, _ = strconv.ParseInt(value, 10, 64)
+		}
+		lock.Lock()
+		last := lastKline
+		lock.Unlock()
+		klines := [][]interface{}{}
+		for i := int64(0); i <= last; i++ {
+			if openTime(i) >= start && openTime(i) <= end {
+				klines = append(klines, []interface{}{openTime(i), "1", "1", "1",
+					strconv.FormatInt(i, 10), "1", openTime(i) + minute - 1, "1", 1, "1", "1", "0"})
+			}
+		}
+		json.NewEncoder(w).Encode(klines)
+		backfilled <- true
+	})
+	upgrader := websocket.Upgrader{}
+	mux.HandleFunc("/ws/ethbtc@kline_1m", func(w http.ResponseWriter, r *http.Request) {
+		ws, err := upgrader.Upgrade(w, r, nil)
+		if err != nil {
+			return
+		}
+		defer ws.Close()
+		lock.Lock()
+		connections++
+		connection := connections
+		lock.Unlock()
+
+		if connection == 1 {
+			ws.WriteMessage(websocket.TextMessage, []byte(streamKline(0, false)))
+			ws.WriteMessage(websocket.TextMessage, []byte(streamKline(0, true)))
+			return
+		}
+
+		// Wait for the backfill after reconnecting, then skip candle 5.
+		select {
+		case <-backfilled:
+		case <-time.After(5 * time.Second):
+		}
+		ws.WriteMessage(websocket.TextMessage, []byte(streamKline(4, false)))
+		lock.Lock()
+		lastKline = 6
+		lock.Unlock()
+		ws.WriteMessage(websocket.TextMessage, []byte(streamKline(6, true)))
+		for {
+			if _, _, err := ws.ReadMessage(); err != nil {
+				return
+			}
+		}
+	})
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	stream := NewKlineStream("ETHBTC", KlineInterval1m,
+		WithRestUrl(server.URL),
+		WithStreamUrl("ws"+strings.TrimPrefix(server.URL, "http")),
+		WithRateLimiter(nil))
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	channel := make(chan KlineStreamEvent)
+	go stream.Run(ctx, channel)
+
+	expected := []struct {
+		eventType  KlineStreamEventType
+		kline      int64
+		backfilled bool
+	}{
+		{KlineStreamUpdate, 0, false},
+		{KlineStreamClosed, 0, false},
+		{KlineStreamDisconnected, 0, false},
+		{KlineStreamReconnected, 0, false},
+		{KlineStreamClosed, 1, true},
+		{KlineStreamClosed, 2, true},
+		{KlineStreamClosed, 3, true},
+		{KlineStreamUpdate, 4, false},
+		{KlineStreamClosed, 4, true},
+		{KlineStreamClosed, 5, true},
+		{KlineStreamClosed, 6, false},
+	}
+	for i, expect := range expected {
+		var event KlineStreamEvent
+		select {
+		case event = <-channel:
+		case <-time.After(5 * time.Second):
+			t.Fatalf("timeout waiting for event %d", i)
+		}
+		if event.Type != expect.eventType || event.Backfilled != expect.backfilled {
+			t.Fatalf("event %d: expected %s (backfilled %v), got %+v", i,
+				expect.eventType, expect.backfilled, event)
+		}
+		if event.Type == KlineStreamUpdate || event.Type == KlineStreamClosed {
+			if event.Kline.OpenTimeMillis != openTime(expect.kline) ||
+				event.Kline.Close.String() != strconv.FormatInt(expect.kline, 10) {
+				t.Fatalf("event %d: expected kline %d, got %+v", i, expect.kline, event.Kline)
+			}
+		}
+		if event.Symbol != "ETHBTC" || event.Interval != KlineInterval1m {
+			t.Errorf("event %d: unexpected symbol or interval: %+v", i, event)
+		}
+	}
+}
+
+func TestKlineStreamBackfillRetry(t *testing.T) {
+	first := int64(1525132800000)
+	minute := int64(60 * 1000)
+	openTime := func(i int64) int64 {
+		return first + i*minute
+	}
+	streamKline := func(i int64, closed bool) string {
+		return fmt.Sprintf(`{"e":"kline","E":%d,"s":"ETHBTC","k":{"t":%d,"T":%d,"s":"ETHBTC","i":"1m","o":"1","c":"%d","x":%v}}`,
+			openTime(i)+1, openTime(i), openTime(i)+minute-1, i, closed)
+	}
+
+	lock := sync.Mutex{}
+	failures := 2
+	sent := make(chan bool)
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/api/v3/time", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprintf(w, `{"serverTime":%d}`, openTime(5)+1)
+	})
+	mux.HandleFunc("/api/v3/klines", func(w http.ResponseWriter, r *http.Request) {
+		lock.Lock()
+		defer lock.Unlock()
+		if failures > 0 {
+			failures--
+			http.Error(w, `{"code":-1000,"msg":"An unknown error occurred."}`,
+				http.StatusInternalServerError)
+			return
+		}
+		// Only succeed once the live candles after the gap have been
+		// sent, so they are received while the backfill runs.
+		<-sent
+		query := r.URL.Query()
+		start, _ := strconv.ParseInt(query.Get("startTime"), 10, 64)
+		end, _ := strconv.ParseInt(query.Get("endTime"), 10, 64)
+		klines := [][]interface{}{}
+		for i := int64(0); i < 5; i++ {
+			if openTime(i) >= start && openTime(i) <= end {
+				klines = append(klines, []interface{}{openTime(i), "1", "1", "1",
+					strconv.FormatInt(i, 10), "1", openTime(i) + minute - 1, "1", 1, "1", "1", "0"})
+			}
+		}
+		json.NewEncoder(w).Encode(klines)
+	})
+	upgrader := websocket.Upgrader{}
+	mux.HandleFunc("/ws/ethbtc@kline_1m", func(w http.ResponseWriter, r *http.Request) {
+		ws, err := upgrader.Upgrade(w, r, nil)
+		if err != nil {
+			return
+		}
+		defer ws.Close()
+		// Candles 1 and 2 are missed.
+		for _, message := range []string{streamKline(0, true), streamKline(3, true),
+			streamKline(4, false), streamKline(4, true)} {
+			ws.WriteMessage(websocket.TextMessage, []byte(message))
+		}
+		close(sent)
+		for {
+			if _, _, err := ws.ReadMessage(); err != nil {
+				return
+			}
+		}
+	})
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	stream := NewKlineStream("ETHBTC", KlineInterval1m,
+		WithRestUrl(server.URL),
+		WithStreamUrl("ws"+strings.TrimPrefix(server.URL, "http")),
+		WithRateLimiter(nil))
+	stream.MinBackoff = 10 * time.Millisecond
+	stream.MaxBackoff = 20 * time.Millisecond
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	channel := make(chan KlineStreamEvent)
+	go stream.Run(ctx, channel)
+
+	// Nothing after the gap is sent until the backfill succeeds.
+	expected := []struct {
+		eventType  KlineStreamEventType
+		kline      int64
+		backfilled bool
+	}{
+		{KlineStreamClosed, 0, false},
+		{KlineStreamError, 0, false},
+		{KlineStreamError, 0, false},
+		{KlineStreamClosed, 1, true},
+		{KlineStreamClosed, 2, true},
+		{KlineStreamClosed, 3, false},
+		{KlineStreamUpdate, 4, false},
+		{KlineStreamClosed, 4, false},
+	}
+	for i, expect := range expected {
+		var event KlineStreamEvent
+		select {
+		case event = <-channel:
+		case <-time.After(5 * time.Second):
+			t.Fatalf("timeout waiting for event %d", i)
+		}
+		if event.Type != expect.eventType || event.Backfilled != expect.backfilled {
+			t.Fatalf("event %d: expected %s (backfilled %v), got %+v", i,
+				expect.eventType, expect.backfilled, event)
+		}
+		if event.Type == KlineStreamError && event.Err == nil {
+			t.Errorf("event %d: expected an error", i)
+		}
+		if event.Type == KlineStreamUpdate || event.Type == KlineStreamClosed {
+			if event.Kline.OpenTimeMillis != openTime(expect.kline) {
+				t.Fatalf("event %d: expected kline %d, got %+v", i, expect.kline, event.Kline)
+			}
+		}
+	}
+}
@@ -0,0 +1,293 @@
+// The MIT License (MIT)
+//
+// Copyright (c) 2018 Cranky Kernel
+//
+// Permission is hereby granted, free of charge, to any person
+// obtaining a copy of this software and associated documentation
+// files (the "Software"), to deal in the Software without
+// restriction, including without limitation the rights to use, copy,
+// modify, merge, publish, distribute, sublicense, and/or sell copies
+// of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be
+// included in all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND,
+// EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF
+// MERCHANTABILITY, FITNESS FOR A PARTICULAR PURPOSE AND
+// NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT HOLDERS
+// BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER IN AN
+// ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN
+// CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package binance
+
+import (
+	"context"
+	"fmt"
+	"math/rand"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gorilla/websocket"
+)
+
+// Default ManagedStream settings.
+const (
+	DEFAULT_STREAM_PING_INTERVAL = 30 * time.Second
+	DEFAULT_STREAM_READ_TIMEOUT  = 90 * time.Second
+	DEFAULT_STREAM_MIN_BACKOFF   = time.Second
+	DEFAULT_STREAM_MAX_BACKOFF   = time.Minute
+)
+
+type ManagedStreamEventType string
+
+const (
+	// The first connection has been made.
+	ManagedStreamConnected ManagedStreamEventType = "CONNECTED"
+
+	// The connection was lost, or a reconnect attempt failed. Messages
+	// may be missed until the next ManagedStreamReconnected.
+	ManagedStreamDisconnected ManagedStreamEventType = "DISCONNECTED"
+
+	// A new connection has been made after a disconnect.
+	ManagedStreamReconnected ManagedStreamEventType = "RECONNECTED"
+
+	// A message was received.
+	ManagedStreamMessage ManagedStreamEventType = "MESSAGE"
+)
+
+type ManagedStreamEvent struct {
+	Type ManagedStreamEventType
+
+	// The message body of a ManagedStreamMessage event.
+	Message []byte
+
+	// The cause of a ManagedStreamDisconnected event.
+	Err error
+
+	// For a ManagedStreamDisconnected event, the delay before the next
+	// connection attempt.
+	RetryIn time.Duration
+}
+
+// ManagedStream is a websocket stream connection that is kept open: it
+// answers and sends pings, treats a connection that has received nothing
+// for ReadTimeout as dead, and reconnects with jittered exponential backoff
+// to the same streams. Binance closes every connection after 24 hours, so
+// long running consumers should use a ManagedStream rather than a
+// StreamClient.
+//
+// The settings may be changed before calling Run.
+type ManagedStream struct {
+	// Use the raw stream endpoint instead of the combined stream endpoint.
+	// Only valid with a single stream. Messages from the combined endpoint
+	// are wrapped in {"stream":...,"data":...}.
+	Single bool
+
+	// How often to ping the server.
+	PingInterval time.Duration
+
+	// How long to wait for a message, ping or pong before reconnecting.
+	ReadTimeout time.Duration
+
+	// The delay before reconnecting doubles with each failed attempt,
+	// from MinBackoff up to MaxBackoff, with random jitter. It is only
+	// reset once a connection has stayed up for MaxBackoff, so a server
+	// that closes each connection at once is not redialled rapidly.
+	MinBackoff time.Duration
+	MaxBackoff time.Duration
+
+	config  clientConfig
+	lock    sync.Mutex
+	streams []string
+
+	// The current connection, nil while disconnected, and the requests
+	// made on it that are waiting for a response.
+	ws        *websocket.Conn
+	writeLock sync.Mutex
+	nextId    int64
+	pending   map[int64]chan streamResponse
+}
+
+// NewManagedStream returns a ManagedStream for streams. Nothing is
+// connected until Run is called.
+func NewManagedStream(streams []string, options ...ClientOption) *ManagedStream {
+	return &ManagedStream{
+		PingInterval: DEFAULT_STREAM_PING_INTERVAL,
+		ReadTimeout:  DEFAULT_STREAM_READ_TIMEOUT,
+		MinBackoff:   DEFAULT_STREAM_MIN_BACKOFF,
+		MaxBackoff:   DEFAULT_STREAM_MAX_BACKOFF,
+		config:       newClientConfig(options...),
+		streams:      append([]string{}, streams...),
+		pending:      map[int64]chan streamResponse{},
+	}
+}
+
+// Streams returns the streams that are connected to on each connection,
+// including those added with Subscribe.
+func (m *ManagedStream) Streams() []string {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	return append([]string{}, m.streams...)
+}
+
+func (m *ManagedStream) path(streams []string) (string, error) {
+	if m.Single {
+		if len(streams) != 1 {
+			return "", fmt.Errorf("single stream endpoint requires exactly one stream")
+		}
+		return fmt.Sprintf("ws/%s", streams[0]), nil
+	}
+	if len(streams) == 0 {
+		return "stream", nil
+	}
+	return fmt.Sprintf("stream?streams=%s", strings.Join(streams, "/")), nil
+}
+
+// Run connects and sends events to channel until ctx is done, returning
+// ctx.Err(). Lost connections are re-established until then.
+func (m *ManagedStream) Run(ctx context.Context, channel chan<- ManagedStreamEvent) error {
+	send := func(event ManagedStreamEvent) error {
+		select {
+		case channel <- event:
+			return nil
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+
+	connected := false
+	attempt := 0
+	for {
+		streams := m.Streams()
+		path, err := m.path(streams)
+		if err != nil {
+			return err
+		}
+		ws, err := openStreamContext(ctx, m.config, path)
+		if err == nil {
+			connectedAt := time.Now()
+			eventType := ManagedStreamConnected
+			if connected {
+				eventType = ManagedStreamReconnected
+			}
+			connected = true
+			if err := send(ManagedStreamEvent{Type: eventType}); err != nil {
+				ws.Close()
+				return err
+			}
+			err = m.read(ctx, ws, streams, send)
+			ws.Close()
+			if time.Since(connectedAt) >= m.MaxBackoff {
+				attempt = 0
+			}
+		}
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+
+		delay := m.backoff(attempt)
+		attempt++
+		if err := send(ManagedStreamEvent{
+			Type:    ManagedStreamDisconnected,
+			Err:     err,
+			RetryIn: delay,
+		}); err != nil {
+			return err
+		}
+		timer := time.NewTimer(delay)
+		select {
+		case <-timer.C:
+		case <-ctx.Done():
+			timer.Stop()
+			return ctx.Err()
+		}
+	}
+}
+
+// read reads messages from ws, which was connected to streams, until it
+// fails, keeping the connection alive with pings and read deadlines.
+func (m *ManagedStream) read(ctx context.Context, ws *websocket.Conn, streams []string, send func(ManagedStreamEvent) error) error {
+	m.setConn(ws)
+	defer m.setConn(nil)
+
+	extendDeadline := func() {
+		if m.ReadTimeout > 0 {
+			ws.SetReadDeadline(time.Now().Add(m.ReadTimeout))
+		}
+	}
+	extendDeadline()
+	ws.SetPingHandler(func(data string) error {
+		extendDeadline()
+		err := ws.WriteControl(websocket.PongMessage, []byte(data),
+			time.Now().Add(10*time.Second))
+		if err == websocket.ErrCloseSent {
+			return nil
+		}
+		return err
+	})
+	ws.SetPongHandler(func(string) error {
+		extendDeadline()
+		return nil
+	})
+
+	stop := make(chan struct{})
+	defer close(stop)
+	closeOnDone(ctx, ws, stop)
+	if m.PingInterval > 0 {
+		go func() {
+			ticker := time.NewTicker(m.PingInterval)
+			defer ticker.Stop()
+			for {
+				select {
+				case <-ticker.C:
+					ws.WriteControl(websocket.PingMessage, nil,
+						time.Now().Add(10*time.Second))
+				case <-stop:
+					return
+				}
+			}
+		}()
+	}
+
+	// Catch up with any subscriptions changed while connecting.
+	go m.resubscribe(ctx, streams)
+
+	for {
+		_, body, err := ws.ReadMessage()
+		if err != nil {
+			return err
+		}
+		extendDeadline()
+		if !m.Single && m.handleResponse(body) {
+			continue
+		}
+		if err := send(ManagedStreamEvent{
+			Type:    ManagedStreamMessage,
+			Message: body,
+		}); err != nil {
+			return err
+		}
+	}
+}
+
+// backoff returns the delay before reconnect attempt, which starts at 0.
+// The delay is randomly chosen between half and all of the exponential
+// backoff so that many clients don't reconnect at the same moment.
+func (m *ManagedStream) backoff(attempt int) time.Duration {
+	delay := m.MinBackoff
+	for i := 0; i < attempt && delay < m.MaxBackoff; i++ {
+		delay *= 2
+	}
+	if delay > m.MaxBackoff {
+		delay = m.MaxBackoff
+	}
+	if delay <= 0 {
+		return 0
+	}
+	return delay/2 + time.Duration(rand.Int63n(int64(delay/2)+1))
+}
@@ -0,0 +1,220 @@
+package binance
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/gorilla/websocket"
+)
+
+func TestManagedStreamReconnect(t *testing.T) {
+	upgrader := websocket.Upgrader{}
+	lock := sync.Mutex{}
+	connections := 0
+	paths := []string{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ws, err := upgrader.Upgrade(w, r, nil)
+		if err != nil {
+			return
+		}
+		defer ws.Close()
+		lock.Lock()
+		connections++
+		connection := connections
+		paths = append(paths, r.URL.String())
+		lock.Unlock()
+
+		ws.WriteMessage(websocket.TextMessage, []byte(`{"stream":"ethbtc@aggTrade","data":{}}`))
+		switch connection {
+		case 1:
+			// Drop the first connection.
+			return
+		case 2:
+			// Go quiet without answering pings so the read times out.
+			time.Sleep(time.Second)
+		default:
+			for {
+				if _, _, err := ws.ReadMessage(); err != nil {
+					return
+				}
+			}
+		}
+	}))
+	defer server.Close()
+
+	stream := NewManagedStream([]string{"ethbtc@aggTrade", "ethbtc@depth"},
+		WithStreamUrl("ws"+strings.TrimPrefix(server.URL, "http")))
+	stream.PingInterval = 50 * time.Millisecond
+	stream.ReadTimeout = 200 * time.Millisecond
+	stream.MinBackoff = time.Millisecond
+	stream.MaxBackoff = 10 * time.Millisecond
+
+	ctx, cancel := context.WithCancel(context.Background())
+	channel := make(chan ManagedStreamEvent)
+	result := make(chan error, 1)
+	go func() {
+		result <- stream.Run(ctx, channel)
+	}()
+
+	expected := []ManagedStreamEventType{
+		ManagedStreamConnected, ManagedStreamMessage, ManagedStreamDisconnected,
+		ManagedStreamReconnected, ManagedStreamMessage, ManagedStreamDisconnected,
+		ManagedStreamReconnected, ManagedStreamMessage,
+	}
+	for i, eventType := range expected {
+		select {
+		case event := <-channel:
+			if event.Type != eventType {
+				t.Fatalf("event %d: expected %s, got %s (%v)", i, eventType, event.Type, event.Err)
+			}
+			if event.Type == ManagedStreamDisconnected && event.Err == nil {
+				t.Errorf("event %d: expected a disconnect cause", i)
+			}
+		case <-time.After(5 * time.Second):
+			t.Fatalf("timeout waiting for event %d: %s", i, eventType)
+		}
+	}
+
+	cancel()
+	if err := <-result; err != context.Canceled {
+		t.Errorf("expected context.Canceled, got %v", err)
+	}
+
+	lock.Lock()
+	defer lock.Unlock()
+	for _, path := range paths {
+		if path != "/stream?streams=ethbtc@aggTrade/ethbtc@depth" {
+			t.Errorf("unexpected path: %s", path)
+		}
+	}
+}
+
+func TestManagedStreamImmediateClose(t *testing.T) {
+	// A server that accepts each connection then closes it at once.
+	upgrader := websocket.Upgrader{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ws, err := upgrader.Upgrade(w, r, nil)
+		if err != nil {
+			return
+		}
+		ws.Close()
+	}))
+	defer server.Close()
+
+	stream := NewManagedStream([]string{"ethbtc@aggTrade"},
+		WithStreamUrl("ws"+strings.TrimPrefix(server.URL, "http")))
+	stream.MinBackoff = 10 * time.Millisecond
+	stream.MaxBackoff = 80 * time.Millisecond
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	channel := make(chan ManagedStreamEvent)
+	go stream.Run(ctx, channel)
+
+	// The connections succeed but the backoff still grows to the maximum.
+	var retryIn time.Duration
+	for disconnects := 0; disconnects < 6; {
+		select {
+		case event := <-channel:
+			if event.Type == ManagedStreamDisconnected {
+				retryIn = event.RetryIn
+				disconnects++
+			}
+		case <-time.After(5 * time.Second):
+			t.Fatalf("timeout waiting for disconnect %d", disconnects)
+		}
+	}
+	if retryIn < stream.MaxBackoff/2 {
+		t.Errorf("expected the backoff to grow, got %v", retryIn)
+	}
+}
+
+func TestManagedStreamBackoff(t *testing.T) {
+	stream := NewManagedStream(nil)
+	for attempt, max := range []time.Duration{time.Second, 2 * time.Second,
+		4 * time.Second, 8 * time.Second} {
+		delay := stream.backoff(attempt)
+		if delay < max/2 || delay > max {
+			t.Errorf("attempt %d: delay %v not between %v and %v", attempt, delay, max/2, max)
+		}
+	}
+	if delay := stream.backoff(100); delay > time.Minute {
+		t.Errorf("expected delay capped at a minute, got %v", delay)
+	}
+}
+
+func TestManagedAggTradeStream(t *testing.T) {
+	upgrader := websocket.Upgrader{}
+	lock := sync.Mutex{}
+	connections := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/ws/ethbtc@aggTrade" {
+			http.NotFound(w, r)
+			return
+		}
+		ws, err := upgrader.Upgrade(w, r, nil)
+		if err != nil {
+			return
+		}
+		defer ws.Close()
+		lock.Lock()
+		connections++
+		connection := connections
+		lock.Unlock()
+
+		ws.WriteMessage(websocket.TextMessage, []byte(fmt.Sprintf(
+			`{"e":"aggTrade","s":"ETHBTC","a":%d,"p":"0.03","q":"1"}`, connection)))
+		if connection == 1 {
+			// Drop the first connection.
+			return
+		}
+		for {
+			if _, _, err := ws.ReadMessage(); err != nil {
+				return
+			}
+		}
+	}))
+	defer server.Close()
+
+	stream := NewManagedAggTradeStream("ETHBTC",
+		WithStreamUrl("ws"+strings.TrimPrefix(server.URL, "http")))
+	stream.Stream.MinBackoff = time.Millisecond
+	stream.Stream.MaxBackoff = time.Millisecond
+
+	ctx, cancel := context.WithCancel(context.Background())
+	channel := make(chan AggTradeStreamEvent)
+	result := make(chan error, 1)
+	go func() {
+		result <- stream.Run(ctx, channel)
+	}()
+
+	next := func() AggTradeStreamEvent {
+		select {
+		case event := <-channel:
+			return event
+		case <-time.After(5 * time.Second):
+			t.Fatal("timeout waiting for event")
+		}
+		return AggTradeStreamEvent{}
+	}
+	if event := next(); event.Trade == nil || event.Trade.TradeID != 1 {
+		t.Fatalf("expected trade 1, got %+v", event)
+	}
+	if event := next(); event.Err == nil {
+		t.Fatalf("expected a disconnect error, got %+v", event)
+	}
+	if event := next(); event.Trade == nil || event.Trade.TradeID != 2 {
+		t.Fatalf("expected trade 2, got %+v", event)
+	}
+
+	cancel()
+	if err := <-result; err != context.Canceled {
+		t.Errorf("expected context.Canceled, got %v", err)
+	}
+}
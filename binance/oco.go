@@ -0,0 +1,224 @@
+// The MIT License (MIT)
+//
+// Copyright (c) 2018 Cranky Kernel
+//
+// Permission is hereby granted, free of charge, to any person
+// obtaining a copy of this software and associated documentation
+// files (the "Software"), to deal in the Software without
+// restriction, including without limitation the rights to use, copy,
+// modify, merge, publish, distribute, sublicense, and/or sell copies
+// of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be
+// included in all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND,
+// EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF
+// MERCHANTABILITY, FITNESS FOR A PARTICULAR PURPOSE AND
+// NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT HOLDERS
+// BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER IN AN
+// ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN
+// CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package binance
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"gitlab.com/crankykernel/cryptotrader/decimal"
+)
+
+// Order list contingency type, status and order status values.
+const (
+	ContingencyTypeOco = "OCO"
+
+	ListStatusTypeResponse    = "RESPONSE"
+	ListStatusTypeExecStarted = "EXEC_STARTED"
+	ListStatusTypeAllDone     = "ALL_DONE"
+
+	ListOrderStatusExecuting = "EXECUTING"
+	ListOrderStatusAllDone   = "ALL_DONE"
+	ListOrderStatusReject    = "REJECT"
+)
+
+// OcoOrderParameters describes an OCO order: a LIMIT_MAKER order at Price
+// and a STOP_LOSS or STOP_LOSS_LIMIT order triggered at StopPrice, where
+// one filling cancels the other.
+type OcoOrderParameters struct {
+	Symbol            string
+	Side              OrderSide
+	Quantity          decimal.Decimal
+	ListClientOrderId string
+
+	// The limit order.
+	Price              decimal.Decimal
+	LimitClientOrderId string
+	LimitIcebergQty    decimal.Decimal
+
+	// The stop order. If StopLimitPrice is set the stop is a
+	// STOP_LOSS_LIMIT order, otherwise a STOP_LOSS order.
+	StopPrice            decimal.Decimal
+	StopLimitPrice       decimal.Decimal
+	StopLimitTimeInForce TimeInForce
+	StopClientOrderId    string
+	StopIcebergQty       decimal.Decimal
+
+	NewOrderRespType OrderResponseType
+}
+
+// Params returns the request parameters for an OCO order, checking that
+// the required parameters are set.
+func (order OcoOrderParameters) Params() (map[string]interface{}, error) {
+	if order.Quantity.Sign() <= 0 {
+		return nil, fmt.Errorf("oco order requires a quantity")
+	}
+	if order.Price.Sign() <= 0 {
+		return nil, fmt.Errorf("oco order requires a price")
+	}
+	if order.StopPrice.Sign() <= 0 {
+		return nil, fmt.Errorf("oco order requires a stop price")
+	}
+
+	params := map[string]interface{}{}
+	params["symbol"] = order.Symbol
+	params["side"] = order.Side
+	params["quantity"] = order.Quantity.String()
+	params["price"] = order.Price.String()
+	params["stopPrice"] = order.StopPrice.String()
+
+	if order.StopLimitPrice.Sign() > 0 {
+		params["stopLimitPrice"] = order.StopLimitPrice.String()
+		timeInForce := order.StopLimitTimeInForce
+		if timeInForce == "" {
+			timeInForce = TimeInForceGTC
+		}
+		params["stopLimitTimeInForce"] = timeInForce
+	} else if order.StopIcebergQty.Sign() > 0 {
+		return nil, fmt.Errorf("stop iceberg quantity requires a stop limit price")
+	}
+
+	if order.ListClientOrderId != "" {
+		params["listClientOrderId"] = order.ListClientOrderId
+	}
+	if order.LimitClientOrderId != "" {
+		params["limitClientOrderId"] = order.LimitClientOrderId
+	}
+	if order.LimitIcebergQty.Sign() > 0 {
+		params["limitIcebergQty"] = order.LimitIcebergQty.String()
+	}
+	if order.StopClientOrderId != "" {
+		params["stopClientOrderId"] = order.StopClientOrderId
+	}
+	if order.StopIcebergQty.Sign() > 0 {
+		params["stopIcebergQty"] = order.StopIcebergQty.String()
+	}
+	if order.NewOrderRespType != "" {
+		params["newOrderRespType"] = order.NewOrderRespType
+	}
+	return params, nil
+}
+
+type OrderListOrder struct {
+	Symbol        string `json:"symbol"`
+	OrderId       int64  `json:"orderId"`
+	ClientOrderId string `json:"clientOrderId"`
+}
+
+// OrderListResponse is an OCO order list. OrderReports is only set when
+// placing or cancelling an order list.
+type OrderListResponse struct {
+	OrderListId           int64               `json:"orderListId"`
+	ContingencyType       string              `json:"contingencyType"`
+	ListStatusType        string              `json:"listStatusType"`
+	ListOrderStatus       string              `json:"listOrderStatus"`
+	ListClientOrderId     string              `json:"listClientOrderId"`
+	TransactionTimeMillis int64               `json:"transactionTime"`
+	Symbol                string              `json:"symbol"`
+	Orders                []OrderListOrder    `json:"orders"`
+	OrderReports          []PostOrderResponse `json:"orderReports"`
+}
+
+// PostOcoOrder places an OCO order.
+func (c *RestClient) PostOcoOrder(order OcoOrderParameters) (*OrderListResponse, error) {
+	params, err := order.Params()
+	if err != nil {
+		return nil, err
+	}
+	httpResponse, err := c.Post("/api/v3/order/oco", params)
+	if err != nil {
+		return nil, err
+	}
+	return decodeOrderListResponse(httpResponse)
+}
+
+// CancelOrderList cancels an entire order list, identified by either its
+// order list ID or its list client order ID.
+func (c *RestClient) CancelOrderList(symbol string, orderListId int64, listClientOrderId string) (*OrderListResponse, error) {
+	params := map[string]interface{}{
+		"symbol": symbol,
+	}
+	if listClientOrderId != "" {
+		params["listClientOrderId"] = listClientOrderId
+	} else {
+		params["orderListId"] = orderListId
+	}
+	httpResponse, err := c.Delete("/api/v3/orderList", params)
+	if err != nil {
+		return nil, err
+	}
+	return decodeOrderListResponse(httpResponse)
+}
+
+// GetOrderList returns an order list, identified by either its order list
+// ID or its list client order ID.
+func (c *RestClient) GetOrderList(orderListId int64, listClientOrderId string) (*OrderListResponse, error) {
+	params := map[string]interface{}{}
+	if listClientOrderId != "" {
+		params["origClientOrderId"] = listClientOrderId
+	} else {
+		params["orderListId"] = orderListId
+	}
+	var response OrderListResponse
+	if err := c.genericGetWithAuthAndDecode("/api/v3/orderList", params, &response); err != nil {
+		return nil, err
+	}
+	return &response, nil
+}
+
+// GetAllOrderLists returns order lists starting from fromId, or the most
+// recent order lists if fromId is -1.
+func (c *RestClient) GetAllOrderLists(fromId int64, limit int64) ([]OrderListResponse, error) {
+	params := map[string]interface{}{}
+	if fromId > -1 {
+		params["fromId"] = fromId
+	}
+	if limit > 0 {
+		params["limit"] = limit
+	}
+	var response []OrderListResponse
+	err := c.genericGetWithAuthAndDecode("/api/v3/allOrderList", params, &response)
+	return response, err
+}
+
+// GetOpenOrderLists returns the order lists that are still executing.
+func (c *RestClient) GetOpenOrderLists() ([]OrderListResponse, error) {
+	var response []OrderListResponse
+	err := c.genericGetWithAuthAndDecode("/api/v3/openOrderList", nil, &response)
+	return response, err
+}
+
+func decodeOrderListResponse(httpResponse *http.Response) (*OrderListResponse, error) {
+	defer httpResponse.Body.Close()
+	if httpResponse.StatusCode != http.StatusOK {
+		return nil, NewRestApiErrorFromResponse(httpResponse)
+	}
+	var response OrderListResponse
+	if err := json.NewDecoder(httpResponse.Body).Decode(&response); err != nil {
+		return nil, err
+	}
+	return &response, nil
+}
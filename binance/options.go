@@ -0,0 +1,133 @@
+// The MIT License (MIT)
+//
+// Copyright (c) 2018 Cranky Kernel
+//
+// Permission is hereby granted, free of charge, to any person
+// obtaining a copy of this software and associated documentation
+// files (the "Software"), to deal in the Software without
+// restriction, including without limitation the rights to use, copy,
+// modify, merge, publish, distribute, sublicense, and/or sell copies
+// of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be
+// included in all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND,
+// EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF
+// MERCHANTABILITY, FITNESS FOR A PARTICULAR PURPOSE AND
+// NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT HOLDERS
+// BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER IN AN
+// ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN
+// CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package binance
+
+import (
+	"net/http"
+	"strings"
+)
+
+const TESTNET_API_ROOT = "https://testnet.binance.vision"
+const TESTNET_WS_STREAM_URL = "wss://testnet.binance.vision"
+
+// The default recvWindow in milliseconds sent with signed requests.
+const DEFAULT_RECV_WINDOW = 5000
+
+type clientConfig struct {
+	restUrl     string
+	streamUrl   string
+	httpClient  *http.Client
+	userAgent   string
+	recvWindow  int64
+	rateLimiter *RateLimiter
+	timeSync    *TimeSync
+}
+
+// ClientOption configures a RestClient, StreamClient or AggTradeStream.
+// Options that do not apply to the type of client are ignored.
+type ClientOption func(*clientConfig)
+
+func newClientConfig(options ...ClientOption) clientConfig {
+	config := clientConfig{
+		restUrl:     API_ROOT,
+		streamUrl:   WS_STREAM_URL,
+		httpClient:  http.DefaultClient,
+		recvWindow:  DEFAULT_RECV_WINDOW,
+		rateLimiter: defaultRateLimiter,
+		timeSync:    defaultTimeSync,
+	}
+	for _, option := range options {
+		option(&config)
+	}
+	return config
+}
+
+// WithRestUrl sets the base URL of the REST API, for example to point the
+// client at the testnet, a mock server or a BinanceApiProxy.
+func WithRestUrl(url string) ClientOption {
+	return func(c *clientConfig) {
+		c.restUrl = strings.TrimRight(url, "/")
+	}
+}
+
+// WithStreamUrl sets the base URL of the websocket streams.
+func WithStreamUrl(url string) ClientOption {
+	return func(c *clientConfig) {
+		c.streamUrl = strings.TrimRight(url, "/")
+	}
+}
+
+// WithTestnet points both the REST API and the websocket streams at the
+// Spot testnet.
+func WithTestnet() ClientOption {
+	return func(c *clientConfig) {
+		c.restUrl = TESTNET_API_ROOT
+		c.streamUrl = TESTNET_WS_STREAM_URL
+	}
+}
+
+// WithHttpClient sets the http.Client used for REST requests. Use this to
+// set timeouts or a custom transport.
+func WithHttpClient(client *http.Client) ClientOption {
+	return func(c *clientConfig) {
+		if client != nil {
+			c.httpClient = client
+		}
+	}
+}
+
+// WithUserAgent sets the User-Agent header sent with REST requests and
+// websocket connections.
+func WithUserAgent(userAgent string) ClientOption {
+	return func(c *clientConfig) {
+		c.userAgent = userAgent
+	}
+}
+
+// WithRecvWindow sets the recvWindow, in milliseconds, sent with signed
+// requests.
+func WithRecvWindow(millis int64) ClientOption {
+	return func(c *clientConfig) {
+		c.recvWindow = millis
+	}
+}
+
+// WithRateLimiter sets the RateLimiter used by a RestClient. By default all
+// clients share a limiter in block mode. A nil limiter disables rate
+// limiting.
+func WithRateLimiter(limiter *RateLimiter) ClientOption {
+	return func(c *clientConfig) {
+		c.rateLimiter = limiter
+	}
+}
+
+// WithTimeSync sets the TimeSync used to compensate for clock skew in signed
+// requests. By default all clients share a TimeSync. A nil TimeSync uses
+// the local clock.
+func WithTimeSync(timeSync *TimeSync) ClientOption {
+	return func(c *clientConfig) {
+		c.timeSync = timeSync
+	}
+}
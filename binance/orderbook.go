@@ -0,0 +1,535 @@
+// The MIT License (MIT)
+//
+// Copyright (c) 2018 Cranky Kernel
+//
+// Permission is hereby granted, free of charge, to any person
+// obtaining a copy of this software and associated documentation
+// files (the "Software"), to deal in the Software without
+// restriction, including without limitation the rights to use, copy,
+// modify, merge, publish, distribute, sublicense, and/or sell copies
+// of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be
+// included in all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND,
+// EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF
+// MERCHANTABILITY, FITNESS FOR A PARTICULAR PURPOSE AND
+// NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT HOLDERS
+// BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER IN AN
+// ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN
+// CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package binance
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gorilla/websocket"
+	"gitlab.com/crankykernel/cryptotrader/decimal"
+)
+
+// The depth snapshot limit used when synchronizing an order book.
+const DEPTH_SNAPSHOT_LIMIT = 1000
+
+// The delay before reconnecting an order book after a stream error.
+const orderBookReconnectDelay = time.Second
+
+// PriceLevel is a price and quantity pair, encoded by Binance as a two
+// element array of strings.
+type PriceLevel struct {
+	Price    decimal.Decimal
+	Quantity decimal.Decimal
+}
+
+func (l *PriceLevel) UnmarshalJSON(b []byte) error {
+	var values []decimal.Decimal
+	if err := json.Unmarshal(b, &values); err != nil {
+		return err
+	}
+	if len(values) < 2 {
+		return fmt.Errorf("invalid price level: %s", b)
+	}
+	l.Price = values[0]
+	l.Quantity = values[1]
+	return nil
+}
+
+func (l PriceLevel) MarshalJSON() ([]byte, error) {
+	return json.Marshal([]decimal.Decimal{l.Price, l.Quantity})
+}
+
+// GET /api/v3/depth
+type DepthResponse struct {
+	LastUpdateId int64        `json:"lastUpdateId"`
+	Bids         []PriceLevel `json:"bids"`
+	Asks         []PriceLevel `json:"asks"`
+}
+
+// GetDepth returns an order book snapshot with up to limit levels on each
+// side. Valid limits are 5, 10, 20, 50, 100, 500, 1000 and 5000.
+func (c *RestClient) GetDepth(symbol string, limit int64) (*DepthResponse, error) {
+	params := map[string]interface{}{
+		"symbol": symbol,
+	}
+	if limit > 0 {
+		params["limit"] = limit
+	}
+	httpResponse, err := c.Get("/api/v3/depth", params)
+	if err != nil {
+		return nil, err
+	}
+	defer httpResponse.Body.Close()
+	if httpResponse.StatusCode != http.StatusOK {
+		return nil, NewRestApiErrorFromResponse(httpResponse)
+	}
+	var response DepthResponse
+	if err := json.NewDecoder(httpResponse.Body).Decode(&response); err != nil {
+		return nil, err
+	}
+	return &response, nil
+}
+
+// bookSide is one side of an order book, kept sorted from the best price.
+type bookSide struct {
+	levels     []PriceLevel
+	descending bool
+}
+
+// search returns the index of price, or where it would be inserted.
+func (s *bookSide) search(price decimal.Decimal) int {
+	return sort.Search(len(s.levels), func(i int) bool {
+		if s.descending {
+			return s.levels[i].Price.Cmp(price) <= 0
+		}
+		return s.levels[i].Price.Cmp(price) >= 0
+	})
+}
+
+// update sets the quantity at a price level, removing the level if the
+// quantity is zero.
+func (s *bookSide) update(level PriceLevel) {
+	i := s.search(level.Price)
+	found := i < len(s.levels) && s.levels[i].Price.Equal(level.Price)
+	switch {
+	case level.Quantity.IsZero():
+		if found {
+			s.levels = append(s.levels[:i], s.levels[i+1:]...)
+		}
+	case found:
+		s.levels[i].Quantity = level.Quantity
+	default:
+		s.levels = append(s.levels, PriceLevel{})
+		copy(s.levels[i+1:], s.levels[i:])
+		s.levels[i] = level
+	}
+}
+
+func (s *bookSide) reset(levels []PriceLevel) {
+	s.levels = s.levels[:0]
+	for _, level := range levels {
+		s.update(level)
+	}
+}
+
+func (s *bookSide) top(n int) []PriceLevel {
+	if n <= 0 || n > len(s.levels) {
+		n = len(s.levels)
+	}
+	levels := make([]PriceLevel, n)
+	copy(levels, s.levels[:n])
+	return levels
+}
+
+// volumeTo sums the quantity of the levels from the best price up to and
+// including price.
+func (s *bookSide) volumeTo(price decimal.Decimal) (quantity decimal.Decimal, quoteQuantity decimal.Decimal) {
+	for _, level := range s.levels {
+		if s.descending && level.Price.LessThan(price) ||
+			!s.descending && level.Price.GreaterThan(price) {
+			break
+		}
+		quantity = quantity.Add(level.Quantity)
+		quoteQuantity = quoteQuantity.Add(level.Quantity.Mul(level.Price))
+	}
+	return quantity, quoteQuantity
+}
+
+// OrderBookGapError is returned when a depth update does not follow on from
+// the last applied update and the order book has to be resynchronized.
+type OrderBookGapError struct {
+	Symbol        string
+	LastUpdateId  int64
+	FirstUpdateId int64
+}
+
+func (e *OrderBookGapError) Error() string {
+	return fmt.Sprintf("%s: depth update gap: last update %d, next update starts at %d",
+		e.Symbol, e.LastUpdateId, e.FirstUpdateId)
+}
+
+// OrderBook is a local copy of the order book for a symbol, built from a
+// depth snapshot and kept up to date from the diff depth stream. It is safe
+// for concurrent use.
+//
+// Until the book is synchronized the query methods return empty results.
+type OrderBook struct {
+	symbol string
+	config clientConfig
+	client *RestClient
+
+	lock         sync.RWMutex
+	bids         bookSide
+	asks         bookSide
+	lastUpdateId int64
+
+	// True once a snapshot has been loaded, false while waiting for one.
+	synced bool
+
+	// True once the first update following the snapshot has been applied.
+	applied bool
+
+	// Updates received while waiting for a snapshot.
+	buffer []*StreamDepthUpdate
+
+	err            error
+	resync         chan struct{}
+	syncedChannel  chan struct{}
+	ws             *websocket.Conn
+	closeRequested bool
+	done           chan struct{}
+}
+
+func newOrderBook(symbol string, options ...ClientOption) *OrderBook {
+	return &OrderBook{
+		symbol:        strings.ToUpper(symbol),
+		config:        newClientConfig(options...),
+		client:        NewAnonymousClient(options...),
+		bids:          bookSide{descending: true},
+		asks:          bookSide{},
+		resync:        make(chan struct{}, 1),
+		syncedChannel: make(chan struct{}),
+		done:          make(chan struct{}),
+	}
+}
+
+// OpenOrderBook starts maintaining an order book for symbol in the
+// background. The book resynchronizes itself from a new snapshot whenever
+// a gap in the depth updates is detected, and reconnects if the stream
+// fails, until Close is called.
+func OpenOrderBook(symbol string, options ...ClientOption) *OrderBook {
+	return OpenOrderBookContext(context.Background(), symbol, options...)
+}
+
+// OpenOrderBookContext is OpenOrderBook, closing the order book when ctx is
+// done.
+func OpenOrderBookContext(ctx context.Context, symbol string, options ...ClientOption) *OrderBook {
+	book := newOrderBook(symbol, options...)
+	book.client = book.client.WithContext(ctx)
+	go book.run()
+	if ctx.Done() != nil {
+		go func() {
+			select {
+			case <-ctx.Done():
+				book.Close()
+			case <-book.done:
+			}
+		}()
+	}
+	return book
+}
+
+// Close stops maintaining the order book.
+func (b *OrderBook) Close() {
+	b.lock.Lock()
+	if b.closeRequested {
+		b.lock.Unlock()
+		return
+	}
+	b.closeRequested = true
+	ws := b.ws
+	b.lock.Unlock()
+	close(b.done)
+	if ws != nil {
+		ws.Close()
+	}
+}
+
+func (b *OrderBook) Symbol() string {
+	return b.symbol
+}
+
+// Synced returns true if the order book is currently synchronized.
+func (b *OrderBook) Synced() bool {
+	b.lock.RLock()
+	defer b.lock.RUnlock()
+	return b.synced
+}
+
+// WaitSynced waits for the order book to be synchronized for the first
+// time.
+func (b *OrderBook) WaitSynced(timeout time.Duration) error {
+	select {
+	case <-b.syncedChannel:
+		return nil
+	case <-b.done:
+		return fmt.Errorf("order book closed")
+	case <-time.After(timeout):
+		if err := b.Err(); err != nil {
+			return err
+		}
+		return fmt.Errorf("timeout waiting for order book to sync")
+	}
+}
+
+// Err returns the last error encountered maintaining the order book. It is
+// cleared when a snapshot is next applied cleanly.
+func (b *OrderBook) Err() error {
+	b.lock.RLock()
+	defer b.lock.RUnlock()
+	return b.err
+}
+
+// LastUpdateId returns the ID of the last update applied to the book.
+func (b *OrderBook) LastUpdateId() int64 {
+	b.lock.RLock()
+	defer b.lock.RUnlock()
+	return b.lastUpdateId
+}
+
+// BestBid returns the highest bid, ok is false if there are no bids.
+func (b *OrderBook) BestBid() (level PriceLevel, ok bool) {
+	b.lock.RLock()
+	defer b.lock.RUnlock()
+	if !b.synced || len(b.bids.levels) == 0 {
+		return level, false
+	}
+	return b.bids.levels[0], true
+}
+
+// BestAsk returns the lowest ask, ok is false if there are no asks.
+func (b *OrderBook) BestAsk() (level PriceLevel, ok bool) {
+	b.lock.RLock()
+	defer b.lock.RUnlock()
+	if !b.synced || len(b.asks.levels) == 0 {
+		return level, false
+	}
+	return b.asks.levels[0], true
+}
+
+// Depth returns up to n levels of each side of the book, best price
+// first. If n is 0 all levels are returned.
+func (b *OrderBook) Depth(n int) (bids []PriceLevel, asks []PriceLevel) {
+	b.lock.RLock()
+	defer b.lock.RUnlock()
+	if !b.synced {
+		return nil, nil
+	}
+	return b.bids.top(n), b.asks.top(n)
+}
+
+// BidVolumeTo returns the total quantity, and its value in the quote asset,
+// of the bids at price or higher. This is what a sell down to price would
+// fill against.
+func (b *OrderBook) BidVolumeTo(price decimal.Decimal) (quantity decimal.Decimal, quoteQuantity decimal.Decimal) {
+	b.lock.RLock()
+	defer b.lock.RUnlock()
+	if !b.synced {
+		return decimal.Zero, decimal.Zero
+	}
+	return b.bids.volumeTo(price)
+}
+
+// AskVolumeTo returns the total quantity, and its value in the quote asset,
+// of the asks at price or lower. This is what a buy up to price would fill
+// against.
+func (b *OrderBook) AskVolumeTo(price decimal.Decimal) (quantity decimal.Decimal, quoteQuantity decimal.Decimal) {
+	b.lock.RLock()
+	defer b.lock.RUnlock()
+	if !b.synced {
+		return decimal.Zero, decimal.Zero
+	}
+	return b.asks.volumeTo(price)
+}
+
+// applySnapshot loads a depth snapshot and applies the buffered updates on
+// top of it. If the buffered updates do not follow on from the snapshot a
+// resync is requested.
+func (b *OrderBook) applySnapshot(snapshot *DepthResponse) error {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+	b.bids.reset(snapshot.Bids)
+	b.asks.reset(snapshot.Asks)
+	b.lastUpdateId = snapshot.LastUpdateId
+	b.synced = true
+	b.applied = false
+	buffer := b.buffer
+	b.buffer = nil
+	for i, update := range buffer {
+		if err := b.applyUpdate(update); err != nil {
+			b.buffer = append(b.buffer, buffer[i+1:]...)
+			return err
+		}
+	}
+	// The book is healthy again, earlier errors no longer apply.
+	b.err = nil
+	select {
+	case <-b.syncedChannel:
+	default:
+		close(b.syncedChannel)
+	}
+	return nil
+}
+
+// handleUpdate applies a depth update, or buffers it if the book is waiting
+// for a snapshot.
+func (b *OrderBook) handleUpdate(update *StreamDepthUpdate) error {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+	if !b.synced {
+		b.buffer = append(b.buffer, update)
+		return nil
+	}
+	return b.applyUpdate(update)
+}
+
+// applyUpdate applies a depth update following the sequence rules for a
+// local order book: updates already covered by the book are dropped, the
+// first update after the snapshot must contain lastUpdateId+1, and each
+// following update must start at the previous update's final ID plus one.
+// On a gap the book is marked unsynced, the update is kept for the next
+// snapshot and a resync is requested. Must be called with the lock held.
+func (b *OrderBook) applyUpdate(update *StreamDepthUpdate) error {
+	if update.FinalUpdateId <= b.lastUpdateId {
+		return nil
+	}
+	if update.FirstUpdateId > b.lastUpdateId+1 ||
+		(b.applied && update.FirstUpdateId != b.lastUpdateId+1) {
+		err := &OrderBookGapError{
+			Symbol:        b.symbol,
+			LastUpdateId:  b.lastUpdateId,
+			FirstUpdateId: update.FirstUpdateId,
+		}
+		b.synced = false
+		b.applied = false
+		b.buffer = []*StreamDepthUpdate{update}
+		b.err = err
+		select {
+		case b.resync <- struct{}{}:
+		default:
+		}
+		return err
+	}
+	for _, level := range update.Bids {
+		b.bids.update(level)
+	}
+	for _, level := range update.Asks {
+		b.asks.update(level)
+	}
+	b.lastUpdateId = update.FinalUpdateId
+	b.applied = true
+	return nil
+}
+
+func (b *OrderBook) setErr(err error) {
+	b.lock.Lock()
+	b.err = err
+	b.lock.Unlock()
+}
+
+// reset discards the book contents, used when the stream is reconnected.
+func (b *OrderBook) reset() {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+	b.bids.reset(nil)
+	b.asks.reset(nil)
+	b.lastUpdateId = 0
+	b.synced = false
+	b.applied = false
+	b.buffer = nil
+	select {
+	case <-b.resync:
+	default:
+	}
+}
+
+func (b *OrderBook) run() {
+	for {
+		err := b.runStream()
+		select {
+		case <-b.done:
+			return
+		default:
+		}
+		if err != nil {
+			b.setErr(err)
+		}
+		b.reset()
+		select {
+		case <-b.done:
+			return
+		case <-time.After(orderBookReconnectDelay):
+		}
+	}
+}
+
+// runStream connects to the diff depth stream and keeps the book in sync
+// until the stream fails or the book is closed.
+func (b *OrderBook) runStream() error {
+	ws, err := openStreamContext(b.client.Context(), b.config,
+		fmt.Sprintf("ws/%s@depth", strings.ToLower(b.symbol)))
+	if err != nil {
+		return err
+	}
+	defer ws.Close()
+
+	b.lock.Lock()
+	if b.closeRequested {
+		b.lock.Unlock()
+		return nil
+	}
+	b.ws = ws
+	b.lock.Unlock()
+
+	streamErr := make(chan error, 1)
+	go func() {
+		for {
+			_, buf, err := ws.ReadMessage()
+			if err != nil {
+				streamErr <- err
+				return
+			}
+			var update StreamDepthUpdate
+			if err := json.Unmarshal(buf, &update); err != nil {
+				streamErr <- err
+				return
+			}
+			b.handleUpdate(&update)
+		}
+	}()
+
+	b.resync <- struct{}{}
+	for {
+		select {
+		case <-b.resync:
+			snapshot, err := b.client.GetDepth(b.symbol, DEPTH_SNAPSHOT_LIMIT)
+			if err != nil {
+				return err
+			}
+			b.applySnapshot(snapshot)
+		case err := <-streamErr:
+			return err
+		case <-b.done:
+			return nil
+		}
+	}
+}
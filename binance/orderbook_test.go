@@ -0,0 +1,134 @@
+package binance
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"gitlab.com/crankykernel/cryptotrader/decimal"
+)
+
+func depthUpdate(first int64, final int64, bids []PriceLevel, asks []PriceLevel) *StreamDepthUpdate {
+	return &StreamDepthUpdate{
+		Symbol:        "ETHBTC",
+		FirstUpdateId: first,
+		FinalUpdateId: final,
+		Bids:          bids,
+		Asks:          asks,
+	}
+}
+
+func level(price string, quantity string) PriceLevel {
+	return PriceLevel{decimal.MustParse(price), decimal.MustParse(quantity)}
+}
+
+func TestDepthResponseUnmarshal(t *testing.T) {
+	body := `{"lastUpdateId":1027024,"bids":[["4.00000000","431.00000000"]],"asks":[["4.00000200","12.00000000"]]}`
+	var depth DepthResponse
+	if err := json.Unmarshal([]byte(body), &depth); err != nil {
+		t.Fatal(err)
+	}
+	if depth.LastUpdateId != 1027024 || len(depth.Bids) != 1 || len(depth.Asks) != 1 {
+		t.Fatalf("unexpected depth: %+v", depth)
+	}
+	if !depth.Asks[0].Price.Equal(decimal.MustParse("4.000002")) ||
+		!depth.Bids[0].Quantity.Equal(decimal.MustParse("431")) {
+		t.Errorf("unexpected levels: %+v", depth)
+	}
+
+	var update StreamDepthUpdate
+	body = `{"e":"depthUpdate","E":123456789,"s":"BNBBTC","U":157,"u":160,"b":[["0.0024","10"]],"a":[["0.0026","100"]]}`
+	if err := json.Unmarshal([]byte(body), &update); err != nil {
+		t.Fatal(err)
+	}
+	if update.FirstUpdateId != 157 || update.FinalUpdateId != 160 || update.EventType != "depthUpdate" {
+		t.Errorf("unexpected update: %+v", update)
+	}
+}
+
+func TestOrderBookSync(t *testing.T) {
+	book := newOrderBook("ETHBTC")
+
+	// Updates are buffered until the snapshot arrives.
+	book.handleUpdate(depthUpdate(90, 99, []PriceLevel{level("0.9", "1")}, nil))
+	book.handleUpdate(depthUpdate(100, 102, []PriceLevel{level("0.97", "3")}, nil))
+	book.handleUpdate(depthUpdate(103, 105, nil, []PriceLevel{level("1.01", "0")}))
+	if _, ok := book.BestBid(); ok {
+		t.Fatalf("expected no bid before the snapshot")
+	}
+
+	err := book.applySnapshot(&DepthResponse{
+		LastUpdateId: 100,
+		Bids:         []PriceLevel{level("0.98", "1"), level("0.99", "2")},
+		Asks:         []PriceLevel{level("1.01", "1"), level("1.02", "2"), level("1.03", "4")},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !book.Synced() || book.LastUpdateId() != 105 {
+		t.Fatalf("expected synced book at 105, got %d", book.LastUpdateId())
+	}
+
+	bid, _ := book.BestBid()
+	ask, _ := book.BestAsk()
+	if bid.Price.String() != "0.99" || ask.Price.String() != "1.02" {
+		t.Errorf("unexpected best bid/ask: %v %v", bid, ask)
+	}
+
+	bids, asks := book.Depth(2)
+	if len(bids) != 2 || bids[1].Price.String() != "0.98" || len(asks) != 2 {
+		t.Errorf("unexpected depth: %v %v", bids, asks)
+	}
+
+	quantity, quoteQuantity := book.AskVolumeTo(decimal.MustParse("1.03"))
+	if quantity.String() != "6" || !quoteQuantity.Equal(decimal.MustParse("6.16")) {
+		t.Errorf("unexpected ask volume: %s %s", quantity, quoteQuantity)
+	}
+	quantity, _ = book.BidVolumeTo(decimal.MustParse("0.975"))
+	if quantity.String() != "3" {
+		t.Errorf("unexpected bid volume: %s", quantity)
+	}
+
+	// A gap marks the book unsynced and requests a resync.
+	err = book.handleUpdate(depthUpdate(107, 108, nil, nil))
+	if _, ok := err.(*OrderBookGapError); !ok {
+		t.Fatalf("expected a gap error, got %v", err)
+	}
+	if book.Synced() {
+		t.Errorf("expected book to be unsynced after a gap")
+	}
+	if _, ok := book.Err().(*OrderBookGapError); !ok {
+		t.Errorf("expected Err to report the gap, got %v", book.Err())
+	}
+	select {
+	case <-book.resync:
+	default:
+		t.Errorf("expected a resync request")
+	}
+	book.handleUpdate(depthUpdate(109, 110, []PriceLevel{level("0.99", "0")}, nil))
+
+	// A snapshot older than the buffered updates is rejected.
+	err = book.applySnapshot(&DepthResponse{LastUpdateId: 104})
+	if _, ok := err.(*OrderBookGapError); !ok {
+		t.Fatalf("expected a gap error for a stale snapshot, got %v", err)
+	}
+	<-book.resync
+
+	err = book.applySnapshot(&DepthResponse{
+		LastUpdateId: 107,
+		Bids:         []PriceLevel{level("0.99", "5"), level("0.96", "1")},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	bid, _ = book.BestBid()
+	if book.LastUpdateId() != 110 || bid.Price.String() != "0.96" {
+		t.Errorf("unexpected book after resync: %d %v", book.LastUpdateId(), bid)
+	}
+	if err := book.Err(); err != nil {
+		t.Errorf("expected no error after resync, got %v", err)
+	}
+	if err := book.WaitSynced(time.Millisecond); err != nil {
+		t.Errorf("expected synced, got %v", err)
+	}
+}
@@ -0,0 +1,154 @@
+// The MIT License (MIT)
+//
+// Copyright (c) 2018 Cranky Kernel
+//
+// Permission is hereby granted, free of charge, to any person
+// obtaining a copy of this software and associated documentation
+// files (the "Software"), to deal in the Software without
+// restriction, including without limitation the rights to use, copy,
+// modify, merge, publish, distribute, sublicense, and/or sell copies
+// of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be
+// included in all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND,
+// EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF
+// MERCHANTABILITY, FITNESS FOR A PARTICULAR PURPOSE AND
+// NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT HOLDERS
+// BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER IN AN
+// ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN
+// CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package binance
+
+import (
+	"encoding/json"
+	"net/http"
+	"time"
+
+	"gitlab.com/crankykernel/cryptotrader/util"
+)
+
+// The maximum number of orders returned by a single allOrders request.
+const ALL_ORDERS_MAX_LIMIT = 1000
+
+// GetOpenOrders returns the open orders for a symbol, or for all symbols if
+// symbol is empty. Querying all symbols has a much higher request weight.
+func (c *RestClient) GetOpenOrders(symbol string) ([]QueryOrderResponse, error) {
+	params := map[string]interface{}{}
+	if symbol != "" {
+		params["symbol"] = symbol
+	}
+	var response []QueryOrderResponse
+	err := c.genericGetWithAuthAndDecode("/api/v3/openOrders", params, &response)
+	return response, err
+}
+
+// AllOrdersParameters selects a page of orders for GetAllOrders. Zero
+// values are not sent.
+type AllOrdersParameters struct {
+	Symbol string
+
+	// Return orders with an order ID greater than or equal to OrderId.
+	OrderId int64
+
+	StartTime time.Time
+	EndTime   time.Time
+	Limit     int64
+}
+
+// GetAllOrders returns a single page of orders for a symbol, including
+// filled and cancelled orders.
+func (c *RestClient) GetAllOrders(query AllOrdersParameters) ([]QueryOrderResponse, error) {
+	params := map[string]interface{}{
+		"symbol": query.Symbol,
+	}
+	if query.OrderId > 0 {
+		params["orderId"] = query.OrderId
+	}
+	if !query.StartTime.IsZero() {
+		params["startTime"] = util.TimeToMillis(query.StartTime)
+	}
+	if !query.EndTime.IsZero() {
+		params["endTime"] = util.TimeToMillis(query.EndTime)
+	}
+	if query.Limit > 0 {
+		params["limit"] = query.Limit
+	}
+	var response []QueryOrderResponse
+	err := c.genericGetWithAuthAndDecode("/api/v3/allOrders", params, &response)
+	return response, err
+}
+
+// GetAllOrdersBetween returns all orders for a symbol created between start
+// and end, either of which may be zero, making as many requests as needed.
+// The first page is selected by start time and following pages by order ID,
+// as Binance limits time range queries to 24 hours.
+func (c *RestClient) GetAllOrdersBetween(symbol string, start time.Time, end time.Time) ([]QueryOrderResponse, error) {
+	query := AllOrdersParameters{
+		Symbol:    symbol,
+		StartTime: start,
+		Limit:     ALL_ORDERS_MAX_LIMIT,
+	}
+	if start.IsZero() {
+		// Without an order ID or start time the most recent orders are
+		// returned.
+		query.OrderId = 1
+	}
+
+	orders := []QueryOrderResponse{}
+	for {
+		page, err := c.GetAllOrders(query)
+		if err != nil {
+			return nil, err
+		}
+		for _, order := range page {
+			if !end.IsZero() && util.MillisToTime(order.TimeMillis).After(end) {
+				return orders, nil
+			}
+			orders = append(orders, order)
+		}
+		if int64(len(page)) < query.Limit {
+			return orders, nil
+		}
+		query.StartTime = time.Time{}
+		query.OrderId = page[len(page)-1].OrderId + 1
+	}
+}
+
+// CancelAllOrders cancels all open orders on a symbol, including the orders
+// of order lists such as OCO orders, returning the cancelled orders.
+func (c *RestClient) CancelAllOrders(symbol string) ([]CancelOrderResponse, error) {
+	params := map[string]interface{}{
+		"symbol": symbol,
+	}
+	httpResponse, err := c.Delete("/api/v3/openOrders", params)
+	if err != nil {
+		return nil, err
+	}
+	defer httpResponse.Body.Close()
+	if httpResponse.StatusCode != http.StatusOK {
+		return nil, NewRestApiErrorFromResponse(httpResponse)
+	}
+
+	// Cancelled order lists are returned with their orders in orderReports.
+	var response []struct {
+		CancelOrderResponse
+		OrderReports []CancelOrderResponse `json:"orderReports"`
+	}
+	if err := json.NewDecoder(httpResponse.Body).Decode(&response); err != nil {
+		return nil, err
+	}
+	cancelled := []CancelOrderResponse{}
+	for _, entry := range response {
+		if len(entry.OrderReports) > 0 {
+			cancelled = append(cancelled, entry.OrderReports...)
+		} else {
+			cancelled = append(cancelled, entry.CancelOrderResponse)
+		}
+	}
+	return cancelled, nil
+}
@@ -0,0 +1,109 @@
+package binance
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+	"time"
+
+	"gitlab.com/crankykernel/cryptotrader/util"
+)
+
+func TestGetAllOrdersBetween(t *testing.T) {
+	// Orders 1 to 2500, one a second.
+	start := time.Date(2018, 5, 1, 0, 0, 0, 0, time.UTC)
+	orderTime := func(orderId int64) int64 {
+		return util.TimeToMillis(start.Add(time.Duration(orderId) * time.Second))
+	}
+
+	requests := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		query := r.URL.Query()
+		limit, _ := strconv.ParseInt(query.Get("limit"), 10, 64)
+		from := int64(1)
+		if value := query.Get("orderId"); value != "" {
+			from, _ = strconv.ParseInt(value, 10, 64)
+		} else if value := query.Get("startTime"); value != "" {
+			millis, _ := strconv.ParseInt(value, 10, 64)
+			for from <= 2500 && orderTime(from) < millis {
+				from++
+			}
+		}
+		orders := []QueryOrderResponse{}
+		for id := from; id <= 2500 && int64(len(orders)) < limit; id++ {
+			orders = append(orders, QueryOrderResponse{
+				Symbol:     "ETHBTC",
+				OrderId:    id,
+				TimeMillis: orderTime(id),
+			})
+		}
+		json.NewEncoder(w).Encode(orders)
+	}))
+	defer server.Close()
+
+	client := NewAuthenticatedClient("key", "secret",
+		WithRestUrl(server.URL),
+		WithTimeSync(nil),
+		WithRateLimiter(nil))
+
+	orders, err := client.GetAllOrdersBetween("ETHBTC", time.Time{}, time.Time{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(orders) != 2500 || requests != 3 {
+		t.Errorf("expected 2500 orders in 3 requests, got %d in %d", len(orders), requests)
+	}
+	for i, order := range orders {
+		if order.OrderId != int64(i+1) {
+			t.Fatalf("expected order %d, got %d", i+1, order.OrderId)
+		}
+	}
+
+	orders, err = client.GetAllOrdersBetween("ETHBTC",
+		start.Add(100*time.Second), start.Add(1500*time.Second))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(orders) != 1401 || orders[0].OrderId != 100 || orders[len(orders)-1].OrderId != 1500 {
+		t.Errorf("expected orders 100 to 1500, got %d orders", len(orders))
+	}
+}
+
+func TestCancelAllOrders(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != "DELETE" || r.URL.Path != "/api/v3/openOrders" {
+			t.Errorf("unexpected request: %s %s", r.Method, r.URL.Path)
+		}
+		w.Write([]byte(`[
+  {"symbol": "BTCUSDT", "origClientOrderId": "E6APeyTJvkMvLMYMqu1KQ4", "orderId": 11, "orderListId": -1, "clientOrderId": "pXLV6Hz6mprAcVYpVMTGgx", "price": "0.089853", "origQty": "0.178622", "executedQty": "0.000000", "status": "CANCELED", "type": "LIMIT", "side": "BUY"},
+  {"orderListId": 1929, "contingencyType": "OCO", "listStatusType": "ALL_DONE", "listOrderStatus": "ALL_DONE", "symbol": "BTCUSDT",
+   "orders": [{"symbol": "BTCUSDT", "orderId": 20, "clientOrderId": "CwOOIPHSmYywx6jZX77TdL"}, {"symbol": "BTCUSDT", "orderId": 21, "clientOrderId": "461cPg51vQjV3zIMOXNz39"}],
+   "orderReports": [
+     {"symbol": "BTCUSDT", "origClientOrderId": "CwOOIPHSmYywx6jZX77TdL", "orderId": 20, "orderListId": 1929, "price": "0.668611", "status": "CANCELED", "type": "STOP_LOSS_LIMIT", "side": "BUY"},
+     {"symbol": "BTCUSDT", "origClientOrderId": "461cPg51vQjV3zIMOXNz39", "orderId": 21, "orderListId": 1929, "price": "0.008791", "status": "CANCELED", "type": "LIMIT_MAKER", "side": "BUY"}
+   ]}
+]`))
+	}))
+	defer server.Close()
+
+	client := NewAuthenticatedClient("key", "secret",
+		WithRestUrl(server.URL),
+		WithTimeSync(nil),
+		WithRateLimiter(nil))
+	cancelled, err := client.CancelAllOrders("BTCUSDT")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(cancelled) != 3 {
+		t.Fatalf("expected 3 cancelled orders, got %d", len(cancelled))
+	}
+	if cancelled[0].OrderID != 11 || cancelled[1].OrderID != 20 || cancelled[2].OrderID != 21 {
+		t.Errorf("unexpected cancelled orders: %+v", cancelled)
+	}
+	if cancelled[2].Type != OrderTypeLimitMaker || cancelled[2].OrderListID != 1929 {
+		t.Errorf("unexpected order report: %+v", cancelled[2])
+	}
+}
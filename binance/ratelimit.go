@@ -0,0 +1,420 @@
+// The MIT License (MIT)
+//
+// Copyright (c) 2018 Cranky Kernel
+//
+// Permission is hereby granted, free of charge, to any person
+// obtaining a copy of this software and associated documentation
+// files (the "Software"), to deal in the Software without
+// restriction, including without limitation the rights to use, copy,
+// modify, merge, publish, distribute, sublicense, and/or sell copies
+// of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be
+// included in all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND,
+// EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF
+// MERCHANTABILITY, FITNESS FOR A PARTICULAR PURPOSE AND
+// NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT HOLDERS
+// BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER IN AN
+// ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN
+// CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package binance
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+type RateLimitMode int
+
+const (
+	// Block until the request can be made without exceeding a limit.
+	RateLimitModeBlock RateLimitMode = iota
+
+	// Return a *RateLimitError instead of making a request that would
+	// exceed a limit.
+	RateLimitModeFailFast
+)
+
+const (
+	RateLimitTypeRequestWeight = "REQUEST_WEIGHT"
+	RateLimitTypeOrders        = "ORDERS"
+	RateLimitTypeRawRequests   = "RAW_REQUESTS"
+)
+
+// The limits used until the limits from the exchange info are loaded.
+var defaultRateLimits = []RateLimit{
+	{RateLimitTypeRequestWeight, "MINUTE", 1, 1200},
+	{RateLimitTypeOrders, "SECOND", 10, 50},
+	{RateLimitTypeOrders, "DAY", 1, 160000},
+}
+
+// The rate limiter shared by all clients that are not given their own.
+// Binance applies request weight limits per IP so sharing one limiter per
+// process is usually what is wanted.
+var defaultRateLimiter = NewRateLimiter(RateLimitModeBlock)
+
+// RateLimitError is returned by a RestClient in fail fast mode when a
+// request would exceed a limit, or while banned after a 429 or 418.
+type RateLimitError struct {
+	// The limit that would be exceeded, ie: REQUEST_WEIGHT 1M.
+	Limit      string
+	RetryAfter time.Duration
+}
+
+func (e *RateLimitError) Error() string {
+	return fmt.Sprintf("rate limit %s exceeded, retry after %v",
+		e.Limit, e.RetryAfter)
+}
+
+// RateLimitUsage is the usage of a single rate limit in the current
+// interval.
+type RateLimitUsage struct {
+	RateLimitType string
+	Interval      string
+	Used          int64
+	Limit         int64
+	ResetsAt      time.Time
+}
+
+func (u RateLimitUsage) Remaining() int64 {
+	if u.Used >= u.Limit {
+		return 0
+	}
+	return u.Limit - u.Used
+}
+
+type rateLimitWindow struct {
+	rateLimitType string
+	key           string
+	duration      time.Duration
+	limit         int64
+	start         time.Time
+	used          int64
+}
+
+func (w *rateLimitWindow) reset(now time.Time) {
+	if start := now.Truncate(w.duration); start.After(w.start) {
+		w.start = start
+		w.used = 0
+	}
+}
+
+func (w *rateLimitWindow) name() string {
+	return fmt.Sprintf("%s %s", w.rateLimitType, w.key)
+}
+
+// RateLimiter tracks request weight and order counts, both as counted
+// locally and as reported by the server in the X-MBX-USED-WEIGHT-* and
+// X-MBX-ORDER-COUNT-* response headers.
+type RateLimiter struct {
+	lock        sync.Mutex
+	mode        RateLimitMode
+	windows     []*rateLimitWindow
+	bannedUntil time.Time
+	now         func() time.Time
+}
+
+func NewRateLimiter(mode RateLimitMode) *RateLimiter {
+	limiter := &RateLimiter{
+		mode: mode,
+		now:  time.Now,
+	}
+	limiter.SetLimits(defaultRateLimits)
+	return limiter
+}
+
+func (l *RateLimiter) SetMode(mode RateLimitMode) {
+	l.lock.Lock()
+	defer l.lock.Unlock()
+	l.mode = mode
+}
+
+// SetLimits replaces the limits, for example with those found in the
+// exchange info. Usage of limits that still exist is kept.
+func (l *RateLimiter) SetLimits(limits []RateLimit) {
+	l.lock.Lock()
+	defer l.lock.Unlock()
+	windows := []*rateLimitWindow{}
+	for _, limit := range limits {
+		key, duration, err := rateLimitInterval(limit.Interval, limit.IntervalNum)
+		if err != nil {
+			continue
+		}
+		window := &rateLimitWindow{
+			rateLimitType: limit.RateLimitType,
+			key:           key,
+			duration:      duration,
+			limit:         limit.Limit,
+		}
+		for _, existing := range l.windows {
+			if existing.name() == window.name() {
+				window.start = existing.start
+				window.used = existing.used
+			}
+		}
+		windows = append(windows, window)
+	}
+	l.windows = windows
+}
+
+// Usage returns the usage of each limit in the current interval.
+func (l *RateLimiter) Usage() []RateLimitUsage {
+	l.lock.Lock()
+	defer l.lock.Unlock()
+	now := l.now()
+	usage := []RateLimitUsage{}
+	for _, window := range l.windows {
+		window.reset(now)
+		usage = append(usage, RateLimitUsage{
+			RateLimitType: window.rateLimitType,
+			Interval:      window.key,
+			Used:          window.used,
+			Limit:         window.limit,
+			ResetsAt:      window.start.Add(window.duration),
+		})
+	}
+	return usage
+}
+
+// BannedUntil returns the time requests may be made again after receiving
+// a 429 or 418. The zero time is returned if not banned.
+func (l *RateLimiter) BannedUntil() time.Time {
+	l.lock.Lock()
+	defer l.lock.Unlock()
+	if l.bannedUntil.After(l.now()) {
+		return l.bannedUntil
+	}
+	return time.Time{}
+}
+
+// Acquire reserves the weight for a request, and an order if the request
+// places one. In block mode it waits until the request can be made; in fail
+// fast mode a *RateLimitError is returned instead.
+func (l *RateLimiter) Acquire(weight int64, orders int64) error {
+	return l.AcquireContext(context.Background(), weight, orders)
+}
+
+// AcquireContext is Acquire, but stops waiting and returns ctx.Err() if ctx
+// is done first.
+func (l *RateLimiter) AcquireContext(ctx context.Context, weight int64, orders int64) error {
+	for {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		wait, err := l.tryAcquire(weight, orders)
+		if err != nil {
+			return err
+		}
+		if wait == 0 {
+			return nil
+		}
+		timer := time.NewTimer(wait)
+		select {
+		case <-timer.C:
+		case <-ctx.Done():
+			timer.Stop()
+			return ctx.Err()
+		}
+	}
+}
+
+func (l *RateLimiter) tryAcquire(weight int64, orders int64) (time.Duration, error) {
+	l.lock.Lock()
+	defer l.lock.Unlock()
+	now := l.now()
+
+	var wait time.Duration
+	limit := ""
+	if l.bannedUntil.After(now) {
+		wait = l.bannedUntil.Sub(now)
+		limit = "BANNED"
+	} else {
+		for _, window := range l.windows {
+			window.reset(now)
+			// A request costing more than the limit is allowed once the
+			// window is empty, otherwise it would never be sent.
+			cost := window.cost(weight, orders)
+			if cost > 0 && window.used > 0 && window.used+cost > window.limit {
+				if resetsIn := window.start.Add(window.duration).Sub(now); resetsIn > wait {
+					wait = resetsIn
+					limit = window.name()
+				}
+			}
+		}
+	}
+
+	if wait > 0 {
+		if l.mode == RateLimitModeFailFast {
+			return 0, &RateLimitError{
+				Limit:      limit,
+				RetryAfter: wait,
+			}
+		}
+		return wait, nil
+	}
+
+	for _, window := range l.windows {
+		window.used += window.cost(weight, orders)
+	}
+	return 0, nil
+}
+
+func (w *rateLimitWindow) cost(weight int64, orders int64) int64 {
+	switch w.rateLimitType {
+	case RateLimitTypeRequestWeight:
+		return weight
+	case RateLimitTypeOrders:
+		return orders
+	case RateLimitTypeRawRequests:
+		return 1
+	}
+	return 0
+}
+
+// Update updates the usage from the response headers, and records a ban
+// if the response is a 429 or 418.
+func (l *RateLimiter) Update(response *http.Response) {
+	l.lock.Lock()
+	defer l.lock.Unlock()
+	now := l.now()
+
+	for _, window := range l.windows {
+		var header string
+		switch window.rateLimitType {
+		case RateLimitTypeRequestWeight:
+			header = response.Header.Get("X-MBX-USED-WEIGHT-" + window.key)
+			if header == "" && window.key == "1M" {
+				header = response.Header.Get("X-MBX-USED-WEIGHT")
+			}
+		case RateLimitTypeOrders:
+			header = response.Header.Get("X-MBX-ORDER-COUNT-" + window.key)
+		}
+		if header == "" {
+			continue
+		}
+		used, err := strconv.ParseInt(header, 10, 64)
+		if err != nil {
+			continue
+		}
+		window.reset(now)
+		window.used = used
+	}
+
+	switch response.StatusCode {
+	case http.StatusTooManyRequests, 418:
+		retryAfter := time.Minute
+		if seconds, err := strconv.ParseInt(response.Header.Get("Retry-After"), 10, 64); err == nil {
+			retryAfter = time.Duration(seconds) * time.Second
+		}
+		if until := now.Add(retryAfter); until.After(l.bannedUntil) {
+			l.bannedUntil = until
+		}
+	}
+}
+
+// rateLimitInterval returns the header suffix (ie: 1M) and duration of a
+// rate limit interval.
+func rateLimitInterval(interval string, num int64) (string, time.Duration, error) {
+	if num < 1 {
+		num = 1
+	}
+	var unit time.Duration
+	switch interval {
+	case "SECOND":
+		unit = time.Second
+	case "MINUTE":
+		unit = time.Minute
+	case "HOUR":
+		unit = time.Hour
+	case "DAY":
+		unit = 24 * time.Hour
+	default:
+		return "", 0, fmt.Errorf("unknown rate limit interval: %s", interval)
+	}
+	return fmt.Sprintf("%d%s", num, interval[0:1]), time.Duration(num) * unit, nil
+}
+
+// endpointWeight returns the request weight of an endpoint. Only /api/
+// endpoints count against the request weight limits.
+func endpointWeight(method string, endpoint string, params map[string]interface{}) int64 {
+	if !strings.HasPrefix(endpoint, "/api/") {
+		return 0
+	}
+	_, hasSymbol := params["symbol"]
+	switch {
+	case method == "GET" && strings.HasSuffix(endpoint, "/depth"):
+		limit := int64(100)
+		if value, ok := params["limit"]; ok {
+			limit, _ = strconv.ParseInt(fmt.Sprintf("%v", value), 10, 64)
+		}
+		switch {
+		case limit <= 100:
+			return 5
+		case limit <= 500:
+			return 25
+		case limit <= 1000:
+			return 50
+		}
+		return 250
+	case method == "GET" && strings.HasSuffix(endpoint, "/ticker/24hr"):
+		if hasSymbol {
+			return 2
+		}
+		return 80
+	case method == "GET" && (strings.HasSuffix(endpoint, "/ticker/price") ||
+		strings.HasSuffix(endpoint, "/ticker/bookTicker")):
+		if hasSymbol {
+			return 2
+		}
+		return 4
+	case method == "GET" && strings.HasSuffix(endpoint, "/openOrders"):
+		if hasSymbol {
+			return 6
+		}
+		return 80
+	case method == "GET" && strings.HasSuffix(endpoint, "/order"):
+		return 4
+	case method == "GET" && strings.HasSuffix(endpoint, "/openOrderList"):
+		return 6
+	case method == "GET" && strings.HasSuffix(endpoint, "/orderList"):
+		return 4
+	case method == "GET" && (strings.HasSuffix(endpoint, "/klines") ||
+		strings.HasSuffix(endpoint, "/aggTrades")):
+		return 2
+	case method == "GET" && (strings.HasSuffix(endpoint, "/trades") ||
+		strings.HasSuffix(endpoint, "/historicalTrades")):
+		return 25
+	case method == "GET" && (strings.HasSuffix(endpoint, "/exchangeInfo") ||
+		strings.HasSuffix(endpoint, "/account") ||
+		strings.HasSuffix(endpoint, "/myTrades") ||
+		strings.HasSuffix(endpoint, "/allOrders") ||
+		strings.HasSuffix(endpoint, "/allOrderList")):
+		return 20
+	}
+	return 1
+}
+
+// endpointOrders returns the number of orders counted against the order
+// rate limits by a request.
+func endpointOrders(method string, endpoint string) int64 {
+	if method != "POST" {
+		return 0
+	}
+	switch endpoint {
+	case "/api/v3/order":
+		return 1
+	case "/api/v3/order/oco":
+		return 2
+	}
+	return 0
+}
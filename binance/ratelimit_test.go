@@ -0,0 +1,87 @@
+package binance
+
+import (
+	"net/http"
+	"testing"
+	"time"
+)
+
+func TestRateLimiterFailFast(t *testing.T) {
+	now := time.Date(2018, 5, 1, 12, 0, 30, 0, time.UTC)
+	limiter := NewRateLimiter(RateLimitModeFailFast)
+	limiter.now = func() time.Time { return now }
+	limiter.SetLimits([]RateLimit{
+		{RateLimitTypeRequestWeight, "MINUTE", 1, 10},
+	})
+
+	if err := limiter.Acquire(8, 0); err != nil {
+		t.Fatal(err)
+	}
+	err := limiter.Acquire(5, 0)
+	rateLimitError, ok := err.(*RateLimitError)
+	if !ok {
+		t.Fatalf("expected *RateLimitError, got %v", err)
+	}
+	if rateLimitError.RetryAfter != 30*time.Second {
+		t.Errorf("unexpected retry after: %v", rateLimitError.RetryAfter)
+	}
+
+	// The next minute resets the window.
+	now = now.Add(30 * time.Second)
+	if err := limiter.Acquire(5, 0); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestRateLimiterUpdate(t *testing.T) {
+	now := time.Date(2018, 5, 1, 12, 0, 0, 0, time.UTC)
+	limiter := NewRateLimiter(RateLimitModeFailFast)
+	limiter.now = func() time.Time { return now }
+
+	response := &http.Response{
+		StatusCode: http.StatusOK,
+		Header:     http.Header{},
+	}
+	response.Header.Set("X-MBX-USED-WEIGHT-1M", "1150")
+	response.Header.Set("X-MBX-ORDER-COUNT-10S", "3")
+	limiter.Update(response)
+
+	for _, usage := range limiter.Usage() {
+		switch usage.RateLimitType + " " + usage.Interval {
+		case "REQUEST_WEIGHT 1M":
+			if usage.Used != 1150 || usage.Remaining() != 50 {
+				t.Errorf("unexpected weight usage: %+v", usage)
+			}
+		case "ORDERS 10S":
+			if usage.Used != 3 {
+				t.Errorf("unexpected order usage: %+v", usage)
+			}
+		}
+	}
+
+	response = &http.Response{
+		StatusCode: http.StatusTooManyRequests,
+		Header:     http.Header{},
+	}
+	response.Header.Set("Retry-After", "120")
+	limiter.Update(response)
+	if err := limiter.Acquire(1, 0); err == nil {
+		t.Fatalf("expected error while banned")
+	}
+	if until := limiter.BannedUntil(); !until.Equal(now.Add(2 * time.Minute)) {
+		t.Errorf("unexpected banned until: %v", until)
+	}
+}
+
+func TestEndpointWeight(t *testing.T) {
+	if weight := endpointWeight("GET", "/api/v3/ticker/24hr", nil); weight != 80 {
+		t.Errorf("expected 80, got %d", weight)
+	}
+	params := map[string]interface{}{"symbol": "ETHBTC", "limit": 1000}
+	if weight := endpointWeight("GET", "/api/v3/depth", params); weight != 50 {
+		t.Errorf("expected 50, got %d", weight)
+	}
+	if weight := endpointWeight("GET", "/sapi/v1/capital/deposit/hisrec", nil); weight != 0 {
+		t.Errorf("expected 0, got %d", weight)
+	}
+}
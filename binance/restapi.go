@@ -30,25 +30,9 @@ import (
 	"fmt"
 	"encoding/json"
 	"bytes"
+	"gitlab.com/crankykernel/cryptotrader/decimal"
 )
 
-type RestApiError struct {
-	StatusCode int
-	Body       []byte
-}
-
-func NewRestApiErrorFromResponse(r *http.Response) *RestApiError {
-	body, _ := ioutil.ReadAll(r.Body)
-	return &RestApiError{
-		StatusCode: r.StatusCode,
-		Body:       body,
-	}
-}
-
-func (e *RestApiError) Error() string {
-	return string(e.Body)
-}
-
 type UserDataStreamResponse struct {
 	ListenKey string `json:"listenKey"`
 }
@@ -97,8 +81,23 @@ const (
 type OrderType string
 
 const (
-	OrderTypeLimit  OrderType = "LIMIT"
-	OrderTypeMarket OrderType = "MARKET"
+	OrderTypeLimit           OrderType = "LIMIT"
+	OrderTypeMarket          OrderType = "MARKET"
+	OrderTypeStopLoss        OrderType = "STOP_LOSS"
+	OrderTypeStopLossLimit   OrderType = "STOP_LOSS_LIMIT"
+	OrderTypeTakeProfit      OrderType = "TAKE_PROFIT"
+	OrderTypeTakeProfitLimit OrderType = "TAKE_PROFIT_LIMIT"
+	OrderTypeLimitMaker      OrderType = "LIMIT_MAKER"
+)
+
+// OrderResponseType selects how much detail is returned when placing an
+// order. MARKET and LIMIT orders default to FULL, other types to ACK.
+type OrderResponseType string
+
+const (
+	OrderResponseTypeAck    OrderResponseType = "ACK"
+	OrderResponseTypeResult OrderResponseType = "RESULT"
+	OrderResponseTypeFull   OrderResponseType = "FULL"
 )
 
 type TimeInForce string
@@ -117,6 +116,9 @@ const (
 	OrderStatusCanceled        OrderStatus = "CANCELED"
 	OrderStatusFilled          OrderStatus = "FILLED"
 	OrderStatusPartiallyFilled OrderStatus = "PARTIALLY_FILLED"
+	OrderStatusPendingCancel   OrderStatus = "PENDING_CANCEL"
+	OrderStatusRejected        OrderStatus = "REJECTED"
+	OrderStatusExpired         OrderStatus = "EXPIRED"
 )
 
 type OrderParameters struct {
@@ -124,44 +126,176 @@ type OrderParameters struct {
 	Side             OrderSide
 	Type             OrderType
 	TimeInForce      TimeInForce
-	Quantity         float64
-	Price            float64
+	Quantity         decimal.Decimal
+	Price            decimal.Decimal
 	NewClientOrderId string
+
+	// For MARKET orders, the amount of the quote asset to spend or receive
+	// instead of a quantity.
+	QuoteOrderQty decimal.Decimal
+
+	// The trigger price of STOP_LOSS and TAKE_PROFIT orders.
+	StopPrice decimal.Decimal
+
+	// Makes a LIMIT, STOP_LOSS_LIMIT or TAKE_PROFIT_LIMIT order an iceberg
+	// order showing only this quantity.
+	IcebergQty decimal.Decimal
+
+	NewOrderRespType OrderResponseType
 }
 
-// TODO: Implement RESULT and FULL response types. Currently only ACK implemented.
-type PostOrderResponse struct {
-	Symbol                string `json:"symbol"`
-	OrderId               int64  `json:"orderId"`
-	ClientOrderId         string `json:"clientOrderId"`
-	TransactionTimeMillis int64  `json:"transactTime"`
+// orderTypeHasPrice returns true if the order type is sent with a price.
+func orderTypeHasPrice(orderType OrderType) bool {
+	switch orderType {
+	case OrderTypeMarket, OrderTypeStopLoss, OrderTypeTakeProfit:
+		return false
+	}
+	return true
 }
 
-func (c *RestClient) PostOrder(order OrderParameters) (*http.Response, error) {
+// orderTypeHasStopPrice returns true if the order type is sent with a stop
+// price.
+func orderTypeHasStopPrice(orderType OrderType) bool {
+	switch orderType {
+	case OrderTypeStopLoss, OrderTypeStopLossLimit,
+		OrderTypeTakeProfit, OrderTypeTakeProfitLimit:
+		return true
+	}
+	return false
+}
+
+// orderTypeHasTimeInForce returns true if the order type requires a time in
+// force.
+func orderTypeHasTimeInForce(orderType OrderType) bool {
+	switch orderType {
+	case OrderTypeLimit, OrderTypeStopLossLimit, OrderTypeTakeProfitLimit:
+		return true
+	}
+	return false
+}
+
+// Params returns the request parameters for an order, checking that the
+// parameters required by the order type are set.
+func (order OrderParameters) Params() (map[string]interface{}, error) {
 	params := map[string]interface{}{}
 	params["symbol"] = order.Symbol
 	params["side"] = order.Side
 	params["type"] = order.Type
-	params["quantity"] = order.Quantity
 
-	switch order.Type {
-	case OrderTypeMarket:
-	default:
-		params["price"] = fmt.Sprintf("%.8f", order.Price)
+	if order.Type == OrderTypeMarket && order.QuoteOrderQty.Sign() > 0 {
+		if order.Quantity.Sign() > 0 {
+			return nil, fmt.Errorf("quantity and quote order quantity are exclusive")
+		}
+		params["quoteOrderQty"] = order.QuoteOrderQty.String()
+	} else if order.Quantity.Sign() > 0 {
+		params["quantity"] = order.Quantity.String()
+	} else {
+		return nil, fmt.Errorf("%s order requires a quantity", order.Type)
+	}
+
+	if orderTypeHasPrice(order.Type) {
+		if order.Price.Sign() <= 0 {
+			return nil, fmt.Errorf("%s order requires a price", order.Type)
+		}
+		params["price"] = order.Price.String()
+	}
+	if orderTypeHasStopPrice(order.Type) {
+		if order.StopPrice.Sign() <= 0 {
+			return nil, fmt.Errorf("%s order requires a stop price", order.Type)
+		}
+		params["stopPrice"] = order.StopPrice.String()
+	}
+	if orderTypeHasTimeInForce(order.Type) {
+		timeInForce := order.TimeInForce
+		if timeInForce == "" {
+			timeInForce = TimeInForceGTC
+		}
+		params["timeInForce"] = timeInForce
+	}
+	if order.IcebergQty.Sign() > 0 {
+		if !orderTypeHasTimeInForce(order.Type) {
+			return nil, fmt.Errorf("%s order cannot be an iceberg order", order.Type)
+		}
+		if params["timeInForce"] != TimeInForceGTC {
+			return nil, fmt.Errorf("iceberg orders require time in force GTC")
+		}
+		params["icebergQty"] = order.IcebergQty.String()
+	}
+	if order.NewClientOrderId != "" {
+		params["newClientOrderId"] = order.NewClientOrderId
+	}
+	if order.NewOrderRespType != "" {
+		params["newOrderRespType"] = order.NewOrderRespType
+	}
+	return params, nil
+}
+
+// A fill as found in the FULL order response.
+type OrderFill struct {
+	Price           decimal.Decimal `json:"price"`
+	Quantity        decimal.Decimal `json:"qty"`
+	Commission      decimal.Decimal `json:"commission"`
+	CommissionAsset string          `json:"commissionAsset"`
+	TradeId         int64           `json:"tradeId"`
+}
+
+// PostOrderResponse is the response to placing an order. Only the first
+// four fields are set for the ACK response type; Fills is only set for the
+// FULL response type.
+type PostOrderResponse struct {
+	Symbol                   string          `json:"symbol"`
+	OrderId                  int64           `json:"orderId"`
+	OrderListId              int64           `json:"orderListId"`
+	ClientOrderId            string          `json:"clientOrderId"`
+	TransactionTimeMillis    int64           `json:"transactTime"`
+	Price                    decimal.Decimal `json:"price"`
+	OriginalQuantity         decimal.Decimal `json:"origQty"`
+	ExecutedQuantity         decimal.Decimal `json:"executedQty"`
+	CummulativeQuoteQuantity decimal.Decimal `json:"cummulativeQuoteQty"`
+	StopPrice                decimal.Decimal `json:"stopPrice"`
+	IcebergQuantity          decimal.Decimal `json:"icebergQty"`
+	Status                   OrderStatus     `json:"status"`
+	TimeInForce              TimeInForce     `json:"timeInForce"`
+	Type                     OrderType       `json:"type"`
+	Side                     OrderSide       `json:"side"`
+	Fills                    []OrderFill     `json:"fills"`
+}
+
+// AveragePrice returns the quantity weighted average price of the fills,
+// or zero if there are no fills.
+func (r *PostOrderResponse) AveragePrice() decimal.Decimal {
+	quantity := decimal.Zero
+	quote := decimal.Zero
+	for _, fill := range r.Fills {
+		quantity = quantity.Add(fill.Quantity)
+		quote = quote.Add(fill.Price.Mul(fill.Quantity))
 	}
-	params["newClientOrderId"] = order.NewClientOrderId
-	if order.TimeInForce != "" {
-		params["timeInForce"] = order.TimeInForce
+	if quantity.IsZero() {
+		return decimal.Zero
 	}
+	return quote.Div(quantity, quote.Scale())
+}
 
-	response, err := c.Post("/api/v3/order", params)
+func (c *RestClient) PostOrder(order OrderParameters) (*PostOrderResponse, error) {
+	params, err := order.Params()
 	if err != nil {
 		return nil, err
 	}
-	if response.StatusCode >= 400 {
-		return response, NewRestApiErrorFromResponse(response)
+
+	httpResponse, err := c.Post("/api/v3/order", params)
+	if err != nil {
+		return nil, err
 	}
-	return response, nil
+	defer httpResponse.Body.Close()
+	if httpResponse.StatusCode != http.StatusOK {
+		return nil, NewRestApiErrorFromResponse(httpResponse)
+	}
+
+	var response PostOrderResponse
+	if err := json.NewDecoder(httpResponse.Body).Decode(&response); err != nil {
+		return nil, err
+	}
+	return &response, nil
 }
 
 func (c *RestClient) CancelOrder(symbol string, orderId int64) (*CancelOrderResponse, error) {
@@ -190,9 +324,28 @@ func (c *RestClient) CancelOrder(symbol string, orderId int64) (*CancelOrderResp
 	return &cancelOrderResponse, nil
 }
 
+// GetExchangeInfo returns the exchange info using an anonymous client with
+// the default options.
+//
+// Deprecated: client options such as the REST URL are ignored, use
+// RestClient.GetExchangeInfo.
 func GetExchangeInfo() (*ExchangeInfoResponse, error) {
-	client := NewAnonymousClient()
-	response, err := client.GetWithAuth("/api/v1/exchangeInfo", nil)
+	return NewAnonymousClient().GetExchangeInfo()
+}
+
+func (c *RestClient) GetExchangeInfo() (*ExchangeInfoResponse, error) {
+	return c.getExchangeInfo(nil)
+}
+
+// GetSymbolExchangeInfo returns the exchange info for a single symbol.
+func (c *RestClient) GetSymbolExchangeInfo(symbol string) (*ExchangeInfoResponse, error) {
+	return c.getExchangeInfo(map[string]interface{}{
+		"symbol": symbol,
+	})
+}
+
+func (c *RestClient) getExchangeInfo(params map[string]interface{}) (*ExchangeInfoResponse, error) {
+	response, err := c.GetWithAuth("/api/v1/exchangeInfo", params)
 	if err != nil {
 		return nil, err
 	}
@@ -211,6 +364,10 @@ func GetExchangeInfo() (*ExchangeInfoResponse, error) {
 	}
 	exchangeInfoResponse.RawResponse = body
 
+	if c.config.rateLimiter != nil && len(exchangeInfoResponse.RateLimits) > 0 {
+		c.config.rateLimiter.SetLimits(exchangeInfoResponse.RateLimits)
+	}
+
 	return &exchangeInfoResponse, nil
 }
 
@@ -296,32 +453,43 @@ func (c *RestClient) GetPriceTicker(symbol string) (PriceTickerResponse, error)
 	return response, err
 }
 
-func (c *RestClient) GetOrderBookTicker(symbol string) (OrderBookTickerResponse, error) {
-	endpoint := "/api/v3/ticker/bookTicker"
+// GetAveragePrice returns the current average price of a symbol.
+func (c *RestClient) GetAveragePrice(symbol string) (AveragePriceResponse, error) {
+	endpoint := "/api/v3/avgPrice"
 	params := map[string]interface{}{
 		"symbol": symbol,
 	}
-	var response OrderBookTickerResponse
+	var response AveragePriceResponse
 	err := c.genericGetAndDecode(endpoint, params, &response)
 	return response, err
 }
 
-func (c *RestClient) GetMytrades(symbol string, limit int64, fromId int64) ([]TradeResponse, error) {
-	endpoint := "/api/v3/myTrades"
+// Return the 24 hour price change statistics for all symbols.
+func (c *RestClient) GetAll24hrTicker() ([]Ticker24hrResponse, error) {
+	endpoint := "/api/v3/ticker/24hr"
+	var response []Ticker24hrResponse
+	err := c.genericGetAndDecode(endpoint, nil, &response)
+	return response, err
+}
+
+func (c *RestClient) GetOrderBookTicker(symbol string) (OrderBookTickerResponse, error) {
+	endpoint := "/api/v3/ticker/bookTicker"
 	params := map[string]interface{}{
 		"symbol": symbol,
 	}
-	if limit > 0 {
-		params["limit"] = limit
-	}
-	if fromId > -1 {
-		params["fromId"] = fromId
-	}
-	var response []TradeResponse
-	err := c.genericGetWithAuthAndDecode(endpoint, params, &response)
+	var response OrderBookTickerResponse
+	err := c.genericGetAndDecode(endpoint, params, &response)
 	return response, err
 }
 
+func (c *RestClient) GetMytrades(symbol string, limit int64, fromId int64) ([]TradeResponse, error) {
+	return c.GetMyTrades(MyTradesParameters{
+		Symbol: symbol,
+		FromId: fromId,
+		Limit:  limit,
+	})
+}
+
 func (c *RestClient) genericGetWithAuthAndDecode(endpoint string, params map[string]interface{}, response interface{}) error {
 	httpResponse, err := c.GetWithAuth(endpoint, params)
 	if err != nil {
@@ -351,3 +519,29 @@ func (c *RestClient) genericGetAndDecode(endpoint string, params map[string]inte
 	decoder := json.NewDecoder(httpResponse.Body)
 	return decoder.Decode(response)
 }
+
+// GetDepositHistory returns the deposit history for an asset, or all assets
+// if asset is empty.
+func (c *RestClient) GetDepositHistory(asset string) ([]DepositHistoryEntry, error) {
+	endpoint := "/sapi/v1/capital/deposit/hisrec"
+	params := map[string]interface{}{}
+	if asset != "" {
+		params["coin"] = asset
+	}
+	var response []DepositHistoryEntry
+	err := c.genericGetWithAuthAndDecode(endpoint, params, &response)
+	return response, err
+}
+
+// GetWithdrawHistory returns the withdrawal history for an asset, or all
+// assets if asset is empty.
+func (c *RestClient) GetWithdrawHistory(asset string) ([]WithdrawHistoryEntry, error) {
+	endpoint := "/sapi/v1/capital/withdraw/history"
+	params := map[string]interface{}{}
+	if asset != "" {
+		params["coin"] = asset
+	}
+	var response []WithdrawHistoryEntry
+	err := c.genericGetWithAuthAndDecode(endpoint, params, &response)
+	return response, err
+}
@@ -0,0 +1,144 @@
+package binance
+
+import (
+	"encoding/json"
+	"testing"
+
+	"gitlab.com/crankykernel/cryptotrader/decimal"
+)
+
+func TestOrderParameters(t *testing.T) {
+	stopLossLimit := OrderParameters{
+		Symbol:     "ETHBTC",
+		Side:       OrderSideSell,
+		Type:       OrderTypeStopLossLimit,
+		Quantity:   decimal.MustParse("1.5"),
+		Price:      decimal.MustParse("0.029"),
+		StopPrice:  decimal.MustParse("0.03"),
+		IcebergQty: decimal.MustParse("0.5"),
+	}
+	params, err := stopLossLimit.Params()
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := map[string]interface{}{
+		"symbol":      "ETHBTC",
+		"side":        OrderSideSell,
+		"type":        OrderTypeStopLossLimit,
+		"quantity":    "1.5",
+		"price":       "0.029",
+		"stopPrice":   "0.03",
+		"timeInForce": TimeInForceGTC,
+		"icebergQty":  "0.5",
+	}
+	if len(params) != len(expected) {
+		t.Errorf("expected params %v, got %v", expected, params)
+	}
+	for key, value := range expected {
+		if params[key] != value {
+			t.Errorf("expected %s=%v, got %v", key, value, params[key])
+		}
+	}
+
+	quoteMarket := OrderParameters{
+		Symbol:        "ETHBTC",
+		Side:          OrderSideBuy,
+		Type:          OrderTypeMarket,
+		QuoteOrderQty: decimal.MustParse("0.1"),
+	}
+	params, err = quoteMarket.Params()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if params["quoteOrderQty"] != "0.1" || params["quantity"] != nil || params["price"] != nil {
+		t.Errorf("unexpected params for quote market order: %v", params)
+	}
+
+	invalid := []OrderParameters{
+		{Symbol: "ETHBTC", Type: OrderTypeLimit, Quantity: decimal.MustParse("1")},
+		{Symbol: "ETHBTC", Type: OrderTypeStopLoss, Quantity: decimal.MustParse("1")},
+		{Symbol: "ETHBTC", Type: OrderTypeMarket},
+		{Symbol: "ETHBTC", Type: OrderTypeMarket, Quantity: decimal.MustParse("1"),
+			IcebergQty: decimal.MustParse("0.1")},
+		{Symbol: "ETHBTC", Type: OrderTypeLimit, Quantity: decimal.MustParse("1"),
+			Price: decimal.MustParse("1"), TimeInForce: TimeInForceIOC,
+			IcebergQty: decimal.MustParse("0.1")},
+	}
+	for _, order := range invalid {
+		if _, err := order.Params(); err == nil {
+			t.Errorf("expected error for %+v", order)
+		}
+	}
+}
+
+func TestPostOrderResponseFull(t *testing.T) {
+	body := `{
+  "symbol": "BTCUSDT",
+  "orderId": 28,
+  "orderListId": -1,
+  "clientOrderId": "6gCrw2kRUAF9CvJDGP16IP",
+  "transactTime": 1507725176595,
+  "price": "0.00000000",
+  "origQty": "10.00000000",
+  "executedQty": "10.00000000",
+  "cummulativeQuoteQty": "10.00000000",
+  "status": "FILLED",
+  "timeInForce": "GTC",
+  "type": "MARKET",
+  "side": "SELL",
+  "fills": [
+    {"price": "4000.00000000", "qty": "1.00000000", "commission": "4.00000000", "commissionAsset": "USDT", "tradeId": 56},
+    {"price": "3999.00000000", "qty": "5.00000000", "commission": "19.99500000", "commissionAsset": "USDT", "tradeId": 57},
+    {"price": "3998.00000000", "qty": "4.00000000", "commission": "15.99200000", "commissionAsset": "USDT", "tradeId": 58}
+  ]
+}`
+	var response PostOrderResponse
+	if err := json.Unmarshal([]byte(body), &response); err != nil {
+		t.Fatal(err)
+	}
+	if response.Status != OrderStatusFilled || response.Type != OrderTypeMarket {
+		t.Errorf("unexpected status or type: %s %s", response.Status, response.Type)
+	}
+	if len(response.Fills) != 3 {
+		t.Fatalf("expected 3 fills, got %d", len(response.Fills))
+	}
+	if fill := response.Fills[1]; fill.TradeId != 57 ||
+		!fill.Commission.Equal(decimal.MustParse("19.995")) {
+		t.Errorf("unexpected fill: %+v", fill)
+	}
+	if price := response.AveragePrice(); !price.Equal(decimal.MustParse("3998.7")) {
+		t.Errorf("expected average price 3998.7, got %s", price)
+	}
+}
+
+func TestOcoOrderParameters(t *testing.T) {
+	order := OcoOrderParameters{
+		Symbol:         "ETHBTC",
+		Side:           OrderSideSell,
+		Quantity:       decimal.MustParse("1"),
+		Price:          decimal.MustParse("0.035"),
+		StopPrice:      decimal.MustParse("0.029"),
+		StopLimitPrice: decimal.MustParse("0.0289"),
+	}
+	params, err := order.Params()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if params["stopLimitPrice"] != "0.0289" || params["stopLimitTimeInForce"] != TimeInForceGTC {
+		t.Errorf("unexpected params: %v", params)
+	}
+
+	order.StopLimitPrice = decimal.Zero
+	params, err = order.Params()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := params["stopLimitTimeInForce"]; ok {
+		t.Errorf("unexpected time in force for stop loss market order: %v", params)
+	}
+
+	order.StopPrice = decimal.Zero
+	if _, err := order.Params(); err == nil {
+		t.Errorf("expected error without a stop price")
+	}
+}
@@ -25,6 +25,7 @@
 package binance
 
 import (
+	"context"
 	"fmt"
 	"net/http"
 	"sort"
@@ -35,6 +36,7 @@ import (
 	"crypto/hmac"
 	"crypto/sha256"
 	"encoding/hex"
+	"gitlab.com/crankykernel/cryptotrader/util"
 )
 
 const API_ROOT = "https://api.binance.com"
@@ -45,111 +47,132 @@ type restClientAuth struct {
 }
 
 type RestClient struct {
-	auth *restClientAuth
+	auth   *restClientAuth
+	config clientConfig
+	ctx    context.Context
 }
 
-func NewAnonymousClient() *RestClient {
+func NewAnonymousClient(options ...ClientOption) *RestClient {
 	return &RestClient{
+		config: newClientConfig(options...),
 	}
 }
 
-func NewAuthenticatedClient(key string, secret string) *RestClient {
+func NewAuthenticatedClient(key string, secret string, options ...ClientOption) *RestClient {
 	return &RestClient{
 		auth: &restClientAuth{
 			ApiKey:    key,
 			ApiSecret: secret,
 		},
+		config: newClientConfig(options...),
 	}
 }
 
-// Perform an unauthenticated GET request.
-func (c *RestClient) Get(endpoint string, params map[string]interface{}) (*http.Response, error) {
-
-	url := fmt.Sprintf("%s%s", API_ROOT, endpoint)
-	queryString := ""
+// WithContext returns a copy of the client that makes its requests with
+// ctx. Cancelling ctx aborts in-flight requests, rate limit waits and
+// multi-request calls such as the downloaders, which then return an error.
+//
+//	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+//	defer cancel()
+//	account, err := client.WithContext(ctx).GetAccount()
+func (c *RestClient) WithContext(ctx context.Context) *RestClient {
+	if ctx == nil {
+		panic("nil context")
+	}
+	client := *c
+	client.ctx = ctx
+	return &client
+}
 
-	if params == nil {
-		params = map[string]interface{}{}
+// Context returns the client's context, context.Background() unless set
+// with WithContext.
+func (c *RestClient) Context() context.Context {
+	if c.ctx != nil {
+		return c.ctx
 	}
+	return context.Background()
+}
 
-	if params != nil {
-		queryString = c.BuildQueryString(params)
-		if queryString != "" {
-			url = fmt.Sprintf("%s?%s", url, queryString)
-		}
-	}
+// The level of authentication to apply to a request.
+type authLevel int
 
-	request, err := http.NewRequest("GET", url, nil)
-	if err != nil {
-		return nil, err
-	}
+const (
+	authNone authLevel = iota
+	authApiKey
+	authSigned
+)
 
-	return http.DefaultClient.Do(request)
+// Perform an unauthenticated GET request.
+func (c *RestClient) Get(endpoint string, params map[string]interface{}) (*http.Response, error) {
+	return c.do("GET", endpoint, params, authNone)
 }
 
 // Perform a fully authenticated GET request.
 func (c *RestClient) GetWithAuth(endpoint string, params map[string]interface{}) (*http.Response, error) {
+	return c.do("GET", endpoint, params, authSigned)
+}
 
-	url := fmt.Sprintf("%s%s", API_ROOT, endpoint)
-	queryString := ""
+func (c *RestClient) Post(endpoint string, params map[string]interface{}) (*http.Response, error) {
+	return c.do("POST", endpoint, params, authSigned)
+}
 
-	if params == nil {
-		params = map[string]interface{}{}
-	}
+// Send a POST request with only the API key and no other authentication.
+func (c *RestClient) PostWithApiKey(endpoint string, params map[string]interface{}) (*http.Response, error) {
+	return c.do("POST", endpoint, params, authApiKey)
+}
 
-	if c.auth != nil {
-		params["recvWindow"] = 5000
-		params["timestamp"] = time.Now().UnixNano() / 1000000
-	}
+func (c *RestClient) Delete(endpoint string, params map[string]interface{}) (*http.Response, error) {
+	return c.do("DELETE", endpoint, params, authSigned)
+}
 
-	if params != nil {
-		queryString = c.BuildQueryString(params)
-		if queryString != "" {
-			url = fmt.Sprintf("%s?%s", url, queryString)
-		}
+func (c *RestClient) DoPut(path string) (*http.Response, error) {
+	return c.do("PUT", path, nil, authApiKey)
+}
+
+// do builds and sends a request. Signed requests are only signed if the
+// client has a secret, otherwise they are sent with just the API key (if
+// available) like an authApiKey request. A signed request that fails with
+// an invalid timestamp error is retried once after re-syncing the time.
+func (c *RestClient) do(method string, endpoint string, params map[string]interface{}, level authLevel) (*http.Response, error) {
+	if params == nil {
+		params = map[string]interface{}{}
 	}
 
-	if c.auth != nil {
-		mac := hmac.New(sha256.New, []byte(c.auth.ApiSecret))
-		mac.Write([]byte(queryString))
-		signature := hex.EncodeToString(mac.Sum(nil))
-		url = fmt.Sprintf("%s&signature=%s",
-			url, signature)
+	sign := level == authSigned && c.canSign()
+	timeSync := c.config.timeSync
+	if sign && timeSync != nil {
+		timeSync.syncIfDue(c)
 	}
 
-	request, err := http.NewRequest("GET", url, nil)
+	response, err := c.send(method, endpoint, params, level, sign)
 	if err != nil {
 		return nil, err
 	}
-
-	if c.auth != nil && c.auth.ApiKey != "" {
-		request.Header.Add("X-MBX-APIKEY", c.auth.ApiKey)
+	if sign && timeSync != nil && isInvalidTimestampResponse(response) {
+		if err := timeSync.Resync(c); err == nil {
+			response.Body.Close()
+			return c.send(method, endpoint, params, level, sign)
+		}
 	}
-
-	return http.DefaultClient.Do(request)
+	return response, nil
 }
 
-func (c *RestClient) Post(endpoint string, params map[string]interface{}) (*http.Response, error) {
-	url := fmt.Sprintf("%s%s", API_ROOT, endpoint)
-	queryString := ""
-
-	if params == nil {
-		params = map[string]interface{}{}
-	}
+// buildUrl returns the URL for a request, adding the recvWindow, timestamp
+// and signature if the request is to be signed.
+func (c *RestClient) buildUrl(endpoint string, params map[string]interface{}, sign bool) string {
+	url := fmt.Sprintf("%s%s", c.config.restUrl, endpoint)
 
-	if c.auth != nil && c.auth.ApiSecret != "" {
-		params["recvWindow"] = 5000
-		params["timestamp"] = time.Now().UnixNano() / 1000000
+	if sign {
+		params["recvWindow"] = c.config.recvWindow
+		params["timestamp"] = util.TimeToMillis(c.now())
 	}
 
-	if params != nil {
-		queryString = c.BuildQueryString(params)
-		if queryString != "" {
-			url = fmt.Sprintf("%s?%s", url, queryString)
-		}
+	queryString := c.BuildQueryString(params)
+	if queryString != "" {
+		url = fmt.Sprintf("%s?%s", url, queryString)
 	}
 
-	if c.auth != nil && c.auth.ApiSecret != "" {
+	if sign {
 		mac := hmac.New(sha256.New, []byte(c.auth.ApiSecret))
 		mac.Write([]byte(queryString))
 		signature := hex.EncodeToString(mac.Sum(nil))
@@ -157,95 +180,61 @@ func (c *RestClient) Post(endpoint string, params map[string]interface{}) (*http
 			url, signature)
 	}
 
-	request, err := http.NewRequest("POST", url, nil)
-	if err != nil {
-		return nil, err
-	}
-
-	if c.auth != nil && c.auth.ApiKey != "" {
-		request.Header.Add("X-MBX-APIKEY", c.auth.ApiKey)
-	}
-
-	return http.DefaultClient.Do(request)
+	return url
 }
 
-// Send a POST request with only the API key and no other authentication.
-func (c *RestClient) PostWithApiKey(endpoint string, params map[string]interface{}) (*http.Response, error) {
-	url := fmt.Sprintf("%s%s", API_ROOT, endpoint)
-	queryString := ""
-
-	if params == nil {
-		params = map[string]interface{}{}
-	}
-
-	if params != nil {
-		queryString = c.BuildQueryString(params)
-		if queryString != "" {
-			url = fmt.Sprintf("%s?%s", url, queryString)
+func (c *RestClient) send(method string, endpoint string, params map[string]interface{}, level authLevel, sign bool) (*http.Response, error) {
+	limiter := c.config.rateLimiter
+	if limiter != nil {
+		weight := endpointWeight(method, endpoint, params)
+		if err := limiter.AcquireContext(c.Context(), weight, endpointOrders(method, endpoint)); err != nil {
+			return nil, err
 		}
 	}
 
-	request, err := http.NewRequest("POST", url, nil)
+	request, err := http.NewRequest(method, c.buildUrl(endpoint, params, sign), nil)
 	if err != nil {
 		return nil, err
 	}
+	request = request.WithContext(c.Context())
 
-	if c.auth != nil && c.auth.ApiKey != "" {
+	if level != authNone && c.auth != nil && c.auth.ApiKey != "" {
 		request.Header.Add("X-MBX-APIKEY", c.auth.ApiKey)
 	}
-
-	return http.DefaultClient.Do(request)
-}
-
-func (c *RestClient) Delete(endpoint string, params map[string]interface{}) (*http.Response, error) {
-	url := fmt.Sprintf("%s%s", API_ROOT, endpoint)
-	queryString := ""
-
-	if params == nil {
-		params = map[string]interface{}{}
-	}
-
-	if c.auth != nil && c.auth.ApiSecret != "" {
-		params["recvWindow"] = 5000
-		params["timestamp"] = time.Now().UnixNano() / 1000000
+	if c.config.userAgent != "" {
+		request.Header.Set("User-Agent", c.config.userAgent)
 	}
 
-	if params != nil {
-		queryString = c.BuildQueryString(params)
-		if queryString != "" {
-			url = fmt.Sprintf("%s?%s", url, queryString)
-		}
-	}
-
-	if c.auth != nil && c.auth.ApiSecret != "" {
-		mac := hmac.New(sha256.New, []byte(c.auth.ApiSecret))
-		mac.Write([]byte(queryString))
-		signature := hex.EncodeToString(mac.Sum(nil))
-		url = fmt.Sprintf("%s&signature=%s",
-			url, signature)
-	}
-
-	request, err := http.NewRequest("DELETE", url, nil)
+	response, err := c.config.httpClient.Do(request)
 	if err != nil {
 		return nil, err
 	}
-
-	if c.auth != nil && c.auth.ApiKey != "" {
-		request.Header.Add("X-MBX-APIKEY", c.auth.ApiKey)
+	if limiter != nil {
+		limiter.Update(response)
 	}
+	return response, nil
+}
 
-	return http.DefaultClient.Do(request)
+// canSign returns true if the client has a secret to sign requests with.
+func (c *RestClient) canSign() bool {
+	return c.auth != nil && c.auth.ApiSecret != ""
 }
 
-func (c *RestClient) DoPut(path string) (*http.Response, error) {
-	url := fmt.Sprintf("%s%s", API_ROOT, path)
-	request, err := http.NewRequest("PUT", url, nil)
-	if err != nil {
-		return nil, err
+// now returns the time to use for the timestamp of signed requests.
+func (c *RestClient) now() time.Time {
+	if c.config.timeSync != nil {
+		return c.config.timeSync.Now()
 	}
-	request.Header.Add("X-MBX-APIKEY", c.auth.ApiKey)
+	return time.Now()
+}
 
-	return http.DefaultClient.Do(request)
+// Usage returns the current rate limit usage so long running tools can
+// throttle themselves. Nil is returned if rate limiting is disabled.
+func (c *RestClient) Usage() []RateLimitUsage {
+	if c.config.rateLimiter == nil {
+		return nil
+	}
+	return c.config.rateLimiter.Usage()
 }
 
 func (c *RestClient) BuildQueryString(params map[string]interface{}) string {
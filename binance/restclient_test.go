@@ -0,0 +1,46 @@
+package binance
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestClientOptions(t *testing.T) {
+	var request *http.Request
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		request = r
+		w.Write([]byte("{}"))
+	}))
+	defer server.Close()
+
+	client := NewAuthenticatedClient("key", "secret",
+		WithRestUrl(server.URL+"/"),
+		WithHttpClient(server.Client()),
+		WithUserAgent("test-agent"),
+		WithRecvWindow(1000),
+		WithTimeSync(nil),
+		WithRateLimiter(nil))
+	response, err := client.GetWithAuth("/api/v3/account", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	response.Body.Close()
+
+	if request.URL.Path != "/api/v3/account" {
+		t.Errorf("unexpected path: %s", request.URL.Path)
+	}
+	if agent := request.Header.Get("User-Agent"); agent != "test-agent" {
+		t.Errorf("unexpected user agent: %s", agent)
+	}
+	if key := request.Header.Get("X-MBX-APIKEY"); key != "key" {
+		t.Errorf("unexpected api key: %s", key)
+	}
+	query := request.URL.Query()
+	if window := query.Get("recvWindow"); window != "1000" {
+		t.Errorf("unexpected recvWindow: %s", window)
+	}
+	if query.Get("signature") == "" {
+		t.Errorf("expected request to be signed")
+	}
+}
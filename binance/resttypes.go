@@ -24,53 +24,85 @@
 
 package binance
 
+import "gitlab.com/crankykernel/cryptotrader/decimal"
+
 type SymbolFilterResponse struct {
-	FilterType  string  `json:"filterType"`
-	MinPrice    float64 `json:"minPrice,string"`
-	MaxPrice    float64 `json:"maxPrice,string"`
-	TickSize    float64 `json:"tickSize,string"`
-	MinQty      float64 `json:"minQty,string"`
-	MaxQty      float64 `json:"maxQty,string"`
-	StepSize    float64 `json:"stepSize,string"`
-	MinNotional float64 `json:"minNotional,string"`
+	FilterType  string          `json:"filterType"`
+	MinPrice    decimal.Decimal `json:"minPrice"`
+	MaxPrice    decimal.Decimal `json:"maxPrice"`
+	TickSize    decimal.Decimal `json:"tickSize"`
+	MinQty      decimal.Decimal `json:"minQty"`
+	MaxQty      decimal.Decimal `json:"maxQty"`
+	StepSize    decimal.Decimal `json:"stepSize"`
+	MinNotional decimal.Decimal `json:"minNotional"`
+
+	// MIN_NOTIONAL and PERCENT_PRICE.
+	ApplyToMarket bool  `json:"applyToMarket"`
+	AvgPriceMins  int64 `json:"avgPriceMins"`
+
+	// PERCENT_PRICE.
+	MultiplierUp   decimal.Decimal `json:"multiplierUp"`
+	MultiplierDown decimal.Decimal `json:"multiplierDown"`
+
+	// MAX_NUM_ORDERS and MAX_NUM_ALGO_ORDERS.
+	MaxNumOrders     int64 `json:"maxNumOrders"`
+	MaxNumAlgoOrders int64 `json:"maxNumAlgoOrders"`
+
+	// ICEBERG_PARTS.
+	Limit int64 `json:"limit"`
 }
 
 type SymbolInfoResponse struct {
 	Symbol              string                 `json:"symbol"`
 	Status              string                 `json:"status"`
 	BaseAsset           string                 `json:"baseAsset"`
-	BaseAssetPrecision  int64                  `json:"baseAssetPrecision`
+	BaseAssetPrecision  int64                  `json:"baseAssetPrecision"`
 	QuoteAsset          string                 `json:"quoteAsset"`
 	QuoteAssetPrecision int64                  `json:"quoteAssetPrecision"`
 	OrderTypes          []string               `json:"orderTypes"`
 	IcebergAllowed      bool                   `json:"icebergAllowed"`
+	OcoAllowed          bool                   `json:"ocoAllowed"`
 	Filters             []SymbolFilterResponse `json:"filters"`
 }
 
+// A rate limit as found in the exchange info, for example REQUEST_WEIGHT
+// with an Interval of MINUTE and IntervalNum of 1.
+type RateLimit struct {
+	RateLimitType string `json:"rateLimitType"`
+	Interval      string `json:"interval"`
+	IntervalNum   int64  `json:"intervalNum"`
+	Limit         int64  `json:"limit"`
+}
+
 type ExchangeInfoResponse struct {
-	Timezone         string `json:"timezone"`
-	ServerTimeMillis int64  `json:"serverTime"`
-	RateLimits []struct {
-		RateLimitType     string `json:"rateLimitType"`
-		RateLimitInterval string `json"rateLimitInterval"`
-		Limit             int64  `json"limit"`
-	}
-	Symbols []SymbolInfoResponse `json:"symbols"`
+	Timezone         string               `json:"timezone"`
+	ServerTimeMillis int64                `json:"serverTime"`
+	RateLimits       []RateLimit          `json:"rateLimits"`
+	Symbols          []SymbolInfoResponse `json:"symbols"`
 
 	RawResponse []byte `json:"-"`
 }
 
 type CancelOrderResponse struct {
-	Symbol            string `json:"symbol"`
-	OrigClientOrderID string `json:"origClientOrderId"`
-	OrderID           int64  `json:"orderId"`
-	ClientOrderID     string `json:"clientOrderId"`
+	Symbol              string          `json:"symbol"`
+	OrigClientOrderID   string          `json:"origClientOrderId"`
+	OrderID             int64           `json:"orderId"`
+	OrderListID         int64           `json:"orderListId"`
+	ClientOrderID       string          `json:"clientOrderId"`
+	Price               decimal.Decimal `json:"price"`
+	OrigQty             decimal.Decimal `json:"origQty"`
+	ExecutedQty         decimal.Decimal `json:"executedQty"`
+	CummulativeQuoteQty decimal.Decimal `json:"cummulativeQuoteQty"`
+	Status              OrderStatus     `json:"status"`
+	TimeInForce         TimeInForce     `json:"timeInForce"`
+	Type                OrderType       `json:"type"`
+	Side                OrderSide       `json:"side"`
 }
 
 type AccountInfoBalance struct {
-	Asset  string  `json:"asset"`
-	Free   float64 `json:"free,string"`
-	Locked float64 `json:"locked,string"`
+	Asset  string          `json:"asset"`
+	Free   decimal.Decimal `json:"free"`
+	Locked decimal.Decimal `json:"locked"`
 }
 
 type AccountInfoResponse struct {
@@ -86,45 +118,112 @@ type AccountInfoResponse struct {
 }
 
 type QueryOrderResponse struct {
-	Symbol        string      `json:"symbol"`
-	OrderId       int64       `json:"orderId"`
-	ClientOrderId string      `json:"clientOrderId"`
-	Price         float64     `json:"price,string"`
-	OrigQty       float64     `json:"origQty,string"`
-	ExecutedQty   float64     `json:"executeQty,string"`
-	Status        OrderStatus `json:"status"`
-	TimeInForce   TimeInForce `json:"timeInForce"`
-	Type          OrderType   `json:"type"`
-	Side          OrderSide   `json:"side"`
-	StopPrice     float64     `json:"stopPrice,string"`
-	IcebergQty    float64     `json:"icebergQty,string"`
-	TimeMillis    int64       `json:"time"`
-	IsWorking     bool        `json:"isWorking"`
+	Symbol        string          `json:"symbol"`
+	OrderId       int64           `json:"orderId"`
+	ClientOrderId string          `json:"clientOrderId"`
+	Price         decimal.Decimal `json:"price"`
+	OrigQty       decimal.Decimal `json:"origQty"`
+	ExecutedQty   decimal.Decimal `json:"executedQty"`
+	Status        OrderStatus     `json:"status"`
+	TimeInForce   TimeInForce     `json:"timeInForce"`
+	Type          OrderType       `json:"type"`
+	Side          OrderSide       `json:"side"`
+	StopPrice     decimal.Decimal `json:"stopPrice"`
+	IcebergQty    decimal.Decimal `json:"icebergQty"`
+	TimeMillis    int64           `json:"time"`
+	IsWorking     bool            `json:"isWorking"`
+
+	OrderListId         int64           `json:"orderListId"`
+	CummulativeQuoteQty decimal.Decimal `json:"cummulativeQuoteQty"`
+	UpdateTimeMillis    int64           `json:"updateTime"`
+}
+
+// The weighted average price over Mins minutes, as used by the
+// PERCENT_PRICE filter.
+type AveragePriceResponse struct {
+	Mins  int64           `json:"mins"`
+	Price decimal.Decimal `json:"price"`
 }
 
 type PriceTickerResponse struct {
-	Symbol string  `json:"symbol"`
-	Price  float64 `json:"price,string"`
+	Symbol string          `json:"symbol"`
+	Price  decimal.Decimal `json:"price"`
 }
 
 type OrderBookTickerResponse struct {
-	Symbol   string  `json:"symbol"`
-	BidPrice float64 `json:"bidPrice,string"`
-	BidQty   float64 `json:"bidQty,string"`
-	AskPrice float64 `json:"askPrice,string"`
-	AskQty   float64 `json:"askQty,string"`
+	Symbol   string          `json:"symbol"`
+	BidPrice decimal.Decimal `json:"bidPrice"`
+	BidQty   decimal.Decimal `json:"bidQty"`
+	AskPrice decimal.Decimal `json:"askPrice"`
+	AskQty   decimal.Decimal `json:"askQty"`
 }
 
 // GET /api/v3/myTrades
 type TradeResponse struct {
-	ID              int64   `json:"id"`
-	OrderID         int64   `json:"orderId"`
-	Price           float64 `json:"price,string"`
-	Quantity        float64 `json:"qty,string"`
-	Commission      float64 `json:"commission,string"`
-	CommissionAsset string  `json:"commissionAsset"`
-	TimeMillis      int64   `json:"time"`
-	IsBuyer         bool    `json:"isBuyer"`
-	IsMaker         bool    `json:"isMaker"`
-	IsBestMatch     bool    `json:"isBestMatch"`
+	Symbol          string          `json:"symbol"`
+	ID              int64           `json:"id"`
+	OrderID         int64           `json:"orderId"`
+	OrderListID     int64           `json:"orderListId"`
+	Price           decimal.Decimal `json:"price"`
+	Quantity        decimal.Decimal `json:"qty"`
+	QuoteQuantity   decimal.Decimal `json:"quoteQty"`
+	Commission      decimal.Decimal `json:"commission"`
+	CommissionAsset string          `json:"commissionAsset"`
+	TimeMillis      int64           `json:"time"`
+	IsBuyer         bool            `json:"isBuyer"`
+	IsMaker         bool            `json:"isMaker"`
+	IsBestMatch     bool            `json:"isBestMatch"`
+}
+
+// GET /api/v3/ticker/24hr
+type Ticker24hrResponse struct {
+	Symbol             string          `json:"symbol"`
+	PriceChange        decimal.Decimal `json:"priceChange"`
+	PriceChangePercent decimal.Decimal `json:"priceChangePercent"`
+	WeightedAvgPrice   decimal.Decimal `json:"weightedAvgPrice"`
+	PrevClosePrice     decimal.Decimal `json:"prevClosePrice"`
+	LastPrice          decimal.Decimal `json:"lastPrice"`
+	LastQty            decimal.Decimal `json:"lastQty"`
+	BidPrice           decimal.Decimal `json:"bidPrice"`
+	BidQty             decimal.Decimal `json:"bidQty"`
+	AskPrice           decimal.Decimal `json:"askPrice"`
+	AskQty             decimal.Decimal `json:"askQty"`
+	OpenPrice          decimal.Decimal `json:"openPrice"`
+	HighPrice          decimal.Decimal `json:"highPrice"`
+	LowPrice           decimal.Decimal `json:"lowPrice"`
+	Volume             decimal.Decimal `json:"volume"`
+	QuoteVolume        decimal.Decimal `json:"quoteVolume"`
+	OpenTimeMillis     int64           `json:"openTime"`
+	CloseTimeMillis    int64           `json:"closeTime"`
+	FirstId            int64           `json:"firstId"`
+	LastId             int64           `json:"lastId"`
+	Count              int64           `json:"count"`
+}
+
+// GET /sapi/v1/capital/deposit/hisrec
+type DepositHistoryEntry struct {
+	Id               string          `json:"id"`
+	Amount           decimal.Decimal `json:"amount"`
+	Coin             string          `json:"coin"`
+	Network          string          `json:"network"`
+	Status           int64           `json:"status"`
+	Address          string          `json:"address"`
+	AddressTag       string          `json:"addressTag"`
+	TxId             string          `json:"txId"`
+	InsertTimeMillis int64           `json:"insertTime"`
+}
+
+// GET /sapi/v1/capital/withdraw/history
+type WithdrawHistoryEntry struct {
+	Id             string          `json:"id"`
+	Amount         decimal.Decimal `json:"amount"`
+	TransactionFee decimal.Decimal `json:"transactionFee"`
+	Coin           string          `json:"coin"`
+	Network        string          `json:"network"`
+	Status         int64           `json:"status"`
+	Address        string          `json:"address"`
+	TxId           string          `json:"txId"`
+
+	// UTC time formatted as "2006-01-02 15:04:05".
+	ApplyTime string `json:"applyTime"`
 }
@@ -25,6 +25,8 @@
 package binance
 
 import (
+	"context"
+	"encoding/json"
 	"github.com/gorilla/websocket"
 	"strings"
 	"fmt"
@@ -34,11 +36,15 @@ import (
 const WS_STREAM_URL = "wss://stream.binance.com:9443"
 
 type StreamClient struct {
-	Conn *websocket.Conn
+	Conn   *websocket.Conn
+	config clientConfig
+
+	// Closed by Close to stop watching the context of ConnectContext.
+	closed chan struct{}
 }
 
-func OpenSingleStream(stream string) (*StreamClient, error) {
-	client := NewStreamClient()
+func OpenSingleStream(stream string, options ...ClientOption) (*StreamClient, error) {
+	client := NewStreamClient(options...)
 	err := client.ConnectSingle(stream)
 	if err != nil {
 		return nil, err
@@ -46,24 +52,53 @@ func OpenSingleStream(stream string) (*StreamClient, error) {
 	return client, nil
 }
 
-func NewStreamClient() *StreamClient {
-	client := &StreamClient{}
+func NewStreamClient(options ...ClientOption) *StreamClient {
+	client := &StreamClient{
+		config: newClientConfig(options...),
+	}
 	return client
 }
 
 func (c *StreamClient) Connect(streams ... string) (err error) {
+	return c.ConnectContext(context.Background(), streams...)
+}
+
+// ConnectContext connects to a combined stream. The connection is closed
+// when ctx is done.
+func (c *StreamClient) ConnectContext(ctx context.Context, streams ... string) (err error) {
 	path := fmt.Sprintf("stream?streams=%s", strings.Join(streams, "/"))
-	c.Conn, err = openStream(path)
-	return err
+	return c.connect(ctx, path)
 }
 
 func (c *StreamClient) ConnectSingle(stream string) (err error) {
+	return c.ConnectSingleContext(context.Background(), stream)
+}
+
+// ConnectSingleContext connects to a single stream. The connection is
+// closed when ctx is done.
+func (c *StreamClient) ConnectSingleContext(ctx context.Context, stream string) (err error) {
 	path := fmt.Sprintf("ws/%s", stream)
-	c.Conn, err = openStream(path)
-	return err
+	return c.connect(ctx, path)
+}
+
+func (c *StreamClient) connect(ctx context.Context, path string) (err error) {
+	c.Conn, err = openStreamContext(ctx, c.config, path)
+	if err != nil {
+		return err
+	}
+	c.closed = make(chan struct{})
+	closeOnDone(ctx, c.Conn, c.closed)
+	return nil
 }
 
 func (c *StreamClient) Close() {
+	if c.closed != nil {
+		select {
+		case <-c.closed:
+		default:
+			close(c.closed)
+		}
+	}
 	c.Conn.Close()
 }
 
@@ -71,6 +106,19 @@ func (c *StreamClient) Next() (messageType int, body []byte, err error) {
 	return c.Conn.ReadMessage()
 }
 
+// NextContext reads the next message. If ctx is done before a message is
+// read the connection is closed and ctx.Err() is returned.
+func (c *StreamClient) NextContext(ctx context.Context) (messageType int, body []byte, err error) {
+	stop := make(chan struct{})
+	closeOnDone(ctx, c.Conn, stop)
+	messageType, body, err = c.Conn.ReadMessage()
+	close(stop)
+	if err != nil && ctx.Err() != nil {
+		return messageType, nil, ctx.Err()
+	}
+	return messageType, body, err
+}
+
 // Next reads the next message into a generic map.
 func (c *StreamClient) NextJSON() (interface{}, error) {
 	var message interface{}
@@ -78,9 +126,43 @@ func (c *StreamClient) NextJSON() (interface{}, error) {
 	return message, err
 }
 
-func openStream(path string) (*websocket.Conn, error) {
-	url := fmt.Sprintf("%s/%s", WS_STREAM_URL, path)
-	ws, httpResponse, err := websocket.DefaultDialer.Dial(url, nil)
+// NextJSONContext is NextJSON, returning ctx.Err() if ctx is done first.
+func (c *StreamClient) NextJSONContext(ctx context.Context) (interface{}, error) {
+	_, body, err := c.NextContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	var message interface{}
+	err = json.Unmarshal(body, &message)
+	return message, err
+}
+
+// closeOnDone closes ws when ctx is done, unless stop is closed first.
+func closeOnDone(ctx context.Context, ws *websocket.Conn, stop <-chan struct{}) {
+	if ctx.Done() == nil {
+		return
+	}
+	go func() {
+		select {
+		case <-ctx.Done():
+			ws.Close()
+		case <-stop:
+		}
+	}()
+}
+
+func openStream(config clientConfig, path string) (*websocket.Conn, error) {
+	return openStreamContext(context.Background(), config, path)
+}
+
+func openStreamContext(ctx context.Context, config clientConfig, path string) (*websocket.Conn, error) {
+	url := fmt.Sprintf("%s/%s", config.streamUrl, path)
+	var header http.Header
+	if config.userAgent != "" {
+		header = http.Header{}
+		header.Set("User-Agent", config.userAgent)
+	}
+	ws, httpResponse, err := websocket.DefaultDialer.DialContext(ctx, url, header)
 	if err != nil {
 		return nil, err
 	}
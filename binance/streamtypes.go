@@ -25,37 +25,40 @@
 package binance
 
 import (
-	"strings"
 	"encoding/json"
 	"fmt"
+	"strconv"
+	"strings"
 	"time"
+
+	"gitlab.com/crankykernel/cryptotrader/decimal"
 )
 
 // Stream name: <symbol>@ticker.
 type Stream24Ticker struct {
-	EventType            string  `json:"e"`
-	EventTime            int64   `json:"E"`
-	Symbol               string  `json:"s"`
-	PriceChange          float64 `json:"p,string"`
-	PriceChangePercent   float64 `json:"P,string"`
-	WeightedAveragePrice float64 `json:"w,string"`
-	PreviousDayClose     float64 `json:"x,string"`
-	CurrentDayClose      float64 `json:"c,string"`
-	CloseTradeQuantity   float64 `json:"Q,string"`
-	Bid                  float64 `json:"b,string"`
-	BidQuantity          float64 `json:"B,string"`
-	Ask                  float64 `json:"a,string"`
-	AskQuantity          float64 `json:"A,string"`
-	OpenPrice            float64 `json:"o,string"`
-	HighPrice            float64 `json:"h,string"`
-	LowPrice             float64 `json:"l,string"`
-	TotalBaseVolume      float64 `json:"v,string"`
-	TotalQuoteVolume     float64 `json:"q,string"`
-	StatsOpenTime        int64   `json:"O"`
-	StatsCloseTime       int64   `json:"C"`
-	FirstTradeID         int64   `json:"F"`
-	LastTradeID          int64   `json:"L"`
-	TotalNumberTrades    int64   `json:"n"`
+	EventType            string          `json:"e"`
+	EventTime            int64           `json:"E"`
+	Symbol               string          `json:"s"`
+	PriceChange          decimal.Decimal `json:"p"`
+	PriceChangePercent   decimal.Decimal `json:"P"`
+	WeightedAveragePrice decimal.Decimal `json:"w"`
+	PreviousDayClose     decimal.Decimal `json:"x"`
+	CurrentDayClose      decimal.Decimal `json:"c"`
+	CloseTradeQuantity   decimal.Decimal `json:"Q"`
+	Bid                  decimal.Decimal `json:"b"`
+	BidQuantity          decimal.Decimal `json:"B"`
+	Ask                  decimal.Decimal `json:"a"`
+	AskQuantity          decimal.Decimal `json:"A"`
+	OpenPrice            decimal.Decimal `json:"o"`
+	HighPrice            decimal.Decimal `json:"h"`
+	LowPrice             decimal.Decimal `json:"l"`
+	TotalBaseVolume      decimal.Decimal `json:"v"`
+	TotalQuoteVolume     decimal.Decimal `json:"q"`
+	StatsOpenTime        int64           `json:"O"`
+	StatsCloseTime       int64           `json:"C"`
+	FirstTradeID         int64           `json:"F"`
+	LastTradeID          int64           `json:"L"`
+	TotalNumberTrades    int64           `json:"n"`
 }
 
 func (t *Stream24Ticker) Timestamp() time.Time {
@@ -67,27 +70,117 @@ type Stream24TickerAll []Stream24Ticker
 
 // Stream name: <symbol>@aggTrade.
 type StreamAggTrade struct {
-	EventType       string  `json:"e"`
-	EventTimeMillis int64   `json:"E"`
-	Symbol          string  `json:"s"`
-	TradeID         int64   `json:"a"`
-	Price           float64 `json:"p,string"`
-	Quantity        float64 `json:"q,string"`
-	FirstTradeID    int64   `json:"f"`
-	LastTradeID     int64   `json:"l"`
-	TradeTimeMillis int64   `json:"T"`
-	BuyerMaker      bool    `json:"m"`
-	Ignored         bool    `json:"M"`
+	EventType       string          `json:"e"`
+	EventTimeMillis int64           `json:"E"`
+	Symbol          string          `json:"s"`
+	TradeID         int64           `json:"a"`
+	Price           decimal.Decimal `json:"p"`
+	Quantity        decimal.Decimal `json:"q"`
+	FirstTradeID    int64           `json:"f"`
+	LastTradeID     int64           `json:"l"`
+	TradeTimeMillis int64           `json:"T"`
+	BuyerMaker      bool            `json:"m"`
+	Ignored         bool            `json:"M"`
 }
 
-func (t *StreamAggTrade) QuoteQuantity() float64 {
-	return t.Quantity * t.Price
+func (t *StreamAggTrade) QuoteQuantity() decimal.Decimal {
+	return t.Quantity.Mul(t.Price)
 }
 
 func (t *StreamAggTrade) Timestamp() time.Time {
 	return time.Unix(0, t.TradeTimeMillis*int64(time.Millisecond))
 }
 
+// Stream name: <symbol>@trade.
+type StreamTrade struct {
+	EventType       string          `json:"e"`
+	EventTimeMillis int64           `json:"E"`
+	Symbol          string          `json:"s"`
+	TradeID         int64           `json:"t"`
+	Price           decimal.Decimal `json:"p"`
+	Quantity        decimal.Decimal `json:"q"`
+	BuyerOrderID    int64           `json:"b"`
+	SellerOrderID   int64           `json:"a"`
+	TradeTimeMillis int64           `json:"T"`
+	BuyerMaker      bool            `json:"m"`
+	Ignored         bool            `json:"M"`
+}
+
+func (t *StreamTrade) Timestamp() time.Time {
+	return time.Unix(0, t.TradeTimeMillis*int64(time.Millisecond))
+}
+
+// Stream name: <symbol>@kline_<interval>.
+type StreamKline struct {
+	EventType       string          `json:"e"`
+	EventTimeMillis int64           `json:"E"`
+	Symbol          string          `json:"s"`
+	Kline           StreamKlineData `json:"k"`
+}
+
+type StreamKlineData struct {
+	OpenTimeMillis      int64           `json:"t"`
+	CloseTimeMillis     int64           `json:"T"`
+	Symbol              string          `json:"s"`
+	Interval            KlineInterval   `json:"i"`
+	FirstTradeID        int64           `json:"f"`
+	LastTradeID         int64           `json:"L"`
+	Open                decimal.Decimal `json:"o"`
+	Close               decimal.Decimal `json:"c"`
+	High                decimal.Decimal `json:"h"`
+	Low                 decimal.Decimal `json:"l"`
+	Volume              decimal.Decimal `json:"v"`
+	TradeCount          int64           `json:"n"`
+	Closed              bool            `json:"x"`
+	QuoteVolume         decimal.Decimal `json:"q"`
+	TakerBuyBaseVolume  decimal.Decimal `json:"V"`
+	TakerBuyQuoteVolume decimal.Decimal `json:"Q"`
+	Ignored             string          `json:"B"`
+}
+
+// ToKline returns the kline in the form returned by GetKlines.
+func (k *StreamKlineData) ToKline() Kline {
+	return Kline{
+		OpenTimeMillis:      k.OpenTimeMillis,
+		Open:                k.Open,
+		High:                k.High,
+		Low:                 k.Low,
+		Close:               k.Close,
+		Volume:              k.Volume,
+		CloseTimeMillis:     k.CloseTimeMillis,
+		QuoteVolume:         k.QuoteVolume,
+		TradeCount:          k.TradeCount,
+		TakerBuyBaseVolume:  k.TakerBuyBaseVolume,
+		TakerBuyQuoteVolume: k.TakerBuyQuoteVolume,
+	}
+}
+
+// Stream name: <symbol>@bookTicker or !bookTicker.
+type StreamBookTicker struct {
+	UpdateID    int64           `json:"u"`
+	Symbol      string          `json:"s"`
+	Bid         decimal.Decimal `json:"b"`
+	BidQuantity decimal.Decimal `json:"B"`
+	Ask         decimal.Decimal `json:"a"`
+	AskQuantity decimal.Decimal `json:"A"`
+}
+
+// Stream name: <symbol>@miniTicker.
+type StreamMiniTicker struct {
+	EventType        string          `json:"e"`
+	EventTimeMillis  int64           `json:"E"`
+	Symbol           string          `json:"s"`
+	ClosePrice       decimal.Decimal `json:"c"`
+	OpenPrice        decimal.Decimal `json:"o"`
+	HighPrice        decimal.Decimal `json:"h"`
+	LowPrice         decimal.Decimal `json:"l"`
+	TotalBaseVolume  decimal.Decimal `json:"v"`
+	TotalQuoteVolume decimal.Decimal `json:"q"`
+}
+
+// Stream name: !miniTicker@arr.
+type StreamMiniTickerAll []StreamMiniTicker
+
 type CombinedStream24TickerAll struct {
 	Stream  string            `json:"stream"`
 	Tickers Stream24TickerAll `json:"data"`
@@ -111,9 +204,34 @@ type CombinedStreamMessage struct {
 	// Data for !ticker@arr messages.
 	Tickers []Stream24Ticker
 
+	// Data for <symbol>@ticker messages.
+	Ticker *Stream24Ticker
+
+	// Data for !miniTicker@arr messages.
+	MiniTickers []StreamMiniTicker
+
+	// Data for <symbol>@miniTicker messages.
+	MiniTicker *StreamMiniTicker
+
 	// Data for <symbol>@aggTrade messages.
 	AggTrade *StreamAggTrade
 
+	// Data for <symbol>@trade messages.
+	Trade *StreamTrade
+
+	// Data for <symbol>@kline_<interval> messages.
+	Kline *StreamKline
+
+	// Data for <symbol>@depth and <symbol>@depth@100ms messages.
+	Depth *StreamDepthUpdate
+
+	// Data for <symbol>@depth<levels> and <symbol>@depth<levels>@100ms
+	// messages.
+	PartialDepth *DepthResponse
+
+	// Data for <symbol>@bookTicker and !bookTicker messages.
+	BookTicker *StreamBookTicker
+
 	// For a stream that is unknown, decode the data into an interface{}.
 	UnknownData interface{}
 
@@ -122,33 +240,44 @@ type CombinedStreamMessage struct {
 
 func (r *CombinedStreamMessage) UnmarshalJSON(b []byte) error {
 	r.Bytes = b
-	prefix := string(b[0:40])
-	if strings.HasPrefix(prefix, `{"stream":"!ticker@arr"`) {
-		var message CombinedStream24TickerAll
-		if err := json.Unmarshal(b, &message); err != nil {
-			return err
-		}
-		r.Stream = message.Stream
-		r.Tickers = message.Tickers
-		return nil
-	} else if strings.Index(prefix, "@aggTrade") > -1 {
-		var message CombinedStreamAggTrade
-		if err := json.Unmarshal(b, &message); err != nil {
-			return err
-		}
-		r.Stream = message.Stream
-		r.AggTrade = &message.AggTrade
-		return nil
-	} else if strings.HasPrefix(prefix, `{"stream":"`) {
-		var message CombinedStreamUnknown
-		if err := json.Unmarshal(b, &message); err != nil {
-			return err
-		}
-		r.Stream = message.Stream
-		r.UnknownData = message.Data
-		return nil
+	var message struct {
+		Stream string          `json:"stream"`
+		Data   json.RawMessage `json:"data"`
+	}
+	if err := json.Unmarshal(b, &message); err != nil || message.Stream == "" {
+		return fmt.Errorf("not part of a multi-stream")
 	}
-	return fmt.Errorf("not part of a multi-stream")
+	r.Stream = message.Stream
+
+	data, err := DecodeStreamData(message.Stream, message.Data)
+	if err != nil {
+		return err
+	}
+	switch data := data.(type) {
+	case Stream24TickerAll:
+		r.Tickers = data
+	case *Stream24Ticker:
+		r.Ticker = data
+	case StreamMiniTickerAll:
+		r.MiniTickers = data
+	case *StreamMiniTicker:
+		r.MiniTicker = data
+	case *StreamAggTrade:
+		r.AggTrade = data
+	case *StreamTrade:
+		r.Trade = data
+	case *StreamKline:
+		r.Kline = data
+	case *StreamDepthUpdate:
+		r.Depth = data
+	case *DepthResponse:
+		r.PartialDepth = data
+	case *StreamBookTicker:
+		r.BookTicker = data
+	default:
+		r.UnknownData = data
+	}
+	return nil
 }
 
 func DecodeRawStreamMessage(b []byte) (CombinedStreamMessage, error) {
@@ -156,3 +285,92 @@ func DecodeRawStreamMessage(b []byte) (CombinedStreamMessage, error) {
 	err := json.Unmarshal(b, &message)
 	return message, err
 }
+
+// DecodeStreamData decodes the data of a message from the named stream,
+// either a raw stream message or the "data" of a combined stream message.
+// The result is a pointer to the stream's type, for example *StreamTrade
+// for <symbol>@trade, a Stream24TickerAll or StreamMiniTickerAll for the
+// all market streams, or the generic decoding into an interface{} for an
+// unknown stream.
+func DecodeStreamData(stream string, data []byte) (interface{}, error) {
+	var v interface{}
+	switch streamType(stream) {
+	case "!ticker@arr":
+		v = &Stream24TickerAll{}
+	case "!miniTicker@arr":
+		v = &StreamMiniTickerAll{}
+	case "!bookTicker", "bookTicker":
+		v = &StreamBookTicker{}
+	case "ticker":
+		v = &Stream24Ticker{}
+	case "miniTicker":
+		v = &StreamMiniTicker{}
+	case "aggTrade":
+		v = &StreamAggTrade{}
+	case "trade":
+		v = &StreamTrade{}
+	case "kline":
+		v = &StreamKline{}
+	case "depth":
+		v = &StreamDepthUpdate{}
+	case "partialDepth":
+		v = &DepthResponse{}
+	default:
+		var unknown interface{}
+		if err := json.Unmarshal(data, &unknown); err != nil {
+			return nil, err
+		}
+		return unknown, nil
+	}
+	if err := json.Unmarshal(data, v); err != nil {
+		return nil, err
+	}
+	switch v := v.(type) {
+	case *Stream24TickerAll:
+		return *v, nil
+	case *StreamMiniTickerAll:
+		return *v, nil
+	}
+	return v, nil
+}
+
+// streamType returns the type of a stream from the suffix of its name: the
+// part after the symbol with any interval, level count or update speed
+// removed, "partialDepth" for the partial book depth streams, or the whole
+// name for the all market streams starting with "!".
+func streamType(stream string) string {
+	if strings.HasPrefix(stream, "!") {
+		return stream
+	}
+	parts := strings.Split(stream, "@")
+	if len(parts) < 2 {
+		return ""
+	}
+	suffix := parts[1]
+	switch {
+	case strings.HasPrefix(suffix, "kline_"):
+		return "kline"
+	case suffix == "depth":
+		return "depth"
+	case strings.HasPrefix(suffix, "depth"):
+		if _, err := strconv.Atoi(suffix[len("depth"):]); err == nil {
+			return "partialDepth"
+		}
+		return ""
+	}
+	return suffix
+}
+
+// Stream name: <symbol>@depth or <symbol>@depth@100ms.
+//
+// Price levels are absolute quantities, a quantity of zero removes the
+// level.
+type StreamDepthUpdate struct {
+	EventType       string       `json:"e"`
+	EventTimeMillis int64        `json:"E"`
+	Symbol          string       `json:"s"`
+	FirstUpdateId   int64        `json:"U"`
+	FinalUpdateId   int64        `json:"u"`
+	Bids            []PriceLevel `json:"b"`
+	Asks            []PriceLevel `json:"a"`
+}
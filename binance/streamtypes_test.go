@@ -0,0 +1,129 @@
+package binance
+
+import (
+	"testing"
+
+	"gitlab.com/crankykernel/cryptotrader/decimal"
+)
+
+var testStreamMessages = map[string]string{
+	"!ticker@arr":     `{"stream":"!ticker@arr","data":[{"e":"24hrTicker","E":123456789,"s":"BNBBTC","c":"0.0025","n":18151}]}`,
+	"ticker":          `{"stream":"bnbbtc@ticker","data":{"e":"24hrTicker","E":123456789,"s":"BNBBTC","p":"0.0015","c":"0.0025","n":18151}}`,
+	"!miniTicker@arr": `{"stream":"!miniTicker@arr","data":[{"e":"24hrMiniTicker","E":123456789,"s":"BNBBTC","c":"0.0025","o":"0.0010"}]}`,
+	"miniTicker":      `{"stream":"bnbbtc@miniTicker","data":{"e":"24hrMiniTicker","E":123456789,"s":"BNBBTC","c":"0.0025","o":"0.0010","h":"0.0025","l":"0.0010","v":"10000","q":"18"}}`,
+	"aggTrade":        `{"stream":"bnbbtc@aggTrade","data":{"e":"aggTrade","E":123456789,"s":"BNBBTC","a":12345,"p":"0.001","q":"100","f":100,"l":105,"T":123456785,"m":true,"M":true}}`,
+	"trade":           `{"stream":"bnbbtc@trade","data":{"e":"trade","E":123456789,"s":"BNBBTC","t":12345,"p":"0.001","q":"100","b":88,"a":50,"T":123456785,"m":true,"M":true}}`,
+	"kline":           `{"stream":"bnbbtc@kline_1m","data":{"e":"kline","E":123456789,"s":"BNBBTC","k":{"t":123400000,"T":123460000,"s":"BNBBTC","i":"1m","f":100,"L":200,"o":"0.0010","c":"0.0020","h":"0.0025","l":"0.0015","v":"1000","n":100,"x":false,"q":"1.0000","V":"500","Q":"0.500","B":"123456"}}}`,
+	"depth":           `{"stream":"bnbbtc@depth@100ms","data":{"e":"depthUpdate","E":123456789,"s":"BNBBTC","U":157,"u":160,"b":[["0.0024","10"]],"a":[["0.0026","100"]]}}`,
+	"partialDepth":    `{"stream":"bnbbtc@depth5","data":{"lastUpdateId":160,"bids":[["0.0024","10"]],"asks":[["0.0026","100"]]}}`,
+	"bookTicker":      `{"stream":"bnbusdt@bookTicker","data":{"u":400900217,"s":"BNBUSDT","b":"25.35190000","B":"31.21000000","a":"25.36520000","A":"40.66000000"}}`,
+	"unknown":         `{"stream":"bnbbtc@avgPrice","data":{"e":"avgPrice","w":"1m","p":"0.0025"}}`,
+}
+
+func TestCombinedStreamMessageDecode(t *testing.T) {
+	decode := func(name string) CombinedStreamMessage {
+		message, err := DecodeRawStreamMessage([]byte(testStreamMessages[name]))
+		if err != nil {
+			t.Fatalf("%s: %v", name, err)
+		}
+		return message
+	}
+
+	if message := decode("!ticker@arr"); len(message.Tickers) != 1 || message.Tickers[0].TotalNumberTrades != 18151 {
+		t.Errorf("unexpected tickers: %+v", message)
+	}
+	if message := decode("ticker"); message.Ticker == nil || message.Ticker.PriceChange.String() != "0.0015" {
+		t.Errorf("unexpected ticker: %+v", message)
+	}
+	if message := decode("!miniTicker@arr"); len(message.MiniTickers) != 1 || message.MiniTickers[0].Symbol != "BNBBTC" {
+		t.Errorf("unexpected mini tickers: %+v", message)
+	}
+	if message := decode("miniTicker"); message.MiniTicker == nil || message.MiniTicker.TotalQuoteVolume.String() != "18" {
+		t.Errorf("unexpected mini ticker: %+v", message)
+	}
+	if message := decode("aggTrade"); message.AggTrade == nil || message.AggTrade.LastTradeID != 105 {
+		t.Errorf("unexpected agg trade: %+v", message)
+	}
+	if message := decode("trade"); message.Trade == nil || message.Trade.BuyerOrderID != 88 ||
+		message.Trade.SellerOrderID != 50 || message.Trade.TradeTimeMillis != 123456785 {
+		t.Errorf("unexpected trade: %+v", message)
+	}
+	if message := decode("kline"); message.Kline == nil || message.Kline.Kline.Interval != KlineInterval1m ||
+		message.Kline.Kline.LastTradeID != 200 || message.Kline.Kline.Low.String() != "0.0015" ||
+		message.Kline.Kline.TakerBuyBaseVolume.String() != "500" {
+		t.Errorf("unexpected kline: %+v", message.Kline)
+	}
+	if message := decode("depth"); message.Depth == nil || message.Depth.FirstUpdateId != 157 ||
+		len(message.Depth.Asks) != 1 {
+		t.Errorf("unexpected depth: %+v", message)
+	}
+	if message := decode("partialDepth"); message.PartialDepth == nil || message.PartialDepth.LastUpdateId != 160 {
+		t.Errorf("unexpected partial depth: %+v", message)
+	}
+	if message := decode("bookTicker"); message.BookTicker == nil || !message.BookTicker.AskQuantity.Equal(decimal.MustParse("40.66")) {
+		t.Errorf("unexpected book ticker: %+v", message)
+	}
+	if message := decode("unknown"); message.UnknownData == nil || message.Stream != "bnbbtc@avgPrice" {
+		t.Errorf("unexpected unknown data: %+v", message)
+	}
+}
+
+func TestCombinedStreamMessageInvalid(t *testing.T) {
+	for _, buf := range []string{
+		``,
+		`{}`,
+		`{"stream":"x"}`,
+		`{"stream":"bnbbtc@trade","data":[]}`,
+		`{"e":"trade","s":"BNBBTC"}`,
+		`null`,
+	} {
+		if _, err := DecodeRawStreamMessage([]byte(buf)); err == nil {
+			t.Errorf("expected an error decoding %q", buf)
+		}
+	}
+}
+
+func TestStreamType(t *testing.T) {
+	for stream, expected := range map[string]string{
+		"bnbbtc@kline_1M":      "kline",
+		"bnbbtc@depth":         "depth",
+		"bnbbtc@depth@100ms":   "depth",
+		"bnbbtc@depth20@100ms": "partialDepth",
+		"bnbbtc@depthx":        "",
+		"!miniTicker@arr":      "!miniTicker@arr",
+		"bnbbtc@aggTrade":      "aggTrade",
+		"bnbbtc":               "",
+		"bnbbtc@ticker_1h":     "ticker_1h",
+		"bnbbtc@bookTicker":    "bookTicker",
+	} {
+		if streamType(stream) != expected {
+			t.Errorf("%s: expected %q, got %q", stream, expected, streamType(stream))
+		}
+	}
+}
+
+func FuzzDecodeRawStreamMessage(f *testing.F) {
+	for _, message := range testStreamMessages {
+		f.Add([]byte(message))
+	}
+	f.Add([]byte(`{"stream":"`))
+	f.Add([]byte(`{"stream":"!ticker@arr"`))
+	f.Add([]byte(`{"stream":"btcusdt@trade","data":{"p":"1e99999999"}}`))
+	f.Fuzz(func(t *testing.T, b []byte) {
+		message, err := DecodeRawStreamMessage(b)
+		if err == nil && message.Stream == "" {
+			t.Errorf("decoded a message without a stream: %q", b)
+		}
+	})
+}
+
+func FuzzDecodeStreamData(f *testing.F) {
+	f.Add("bnbbtc@depth5", []byte(`{"lastUpdateId":160,"bids":[["0.0024"]]}`))
+	f.Add("bnbbtc@kline_1m", []byte(`{"k":{"o":"1e"}}`))
+	f.Add("@", []byte(`{}`))
+	f.Add("btcusdt@trade", []byte(`{"p":"1e99999999"}`))
+	f.Add("btcusdt@trade", []byte(`{"p":"1e-2147483648"}`))
+	f.Fuzz(func(t *testing.T, stream string, data []byte) {
+		DecodeStreamData(stream, data)
+	})
+}
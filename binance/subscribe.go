@@ -0,0 +1,281 @@
+// The MIT License (MIT)
+//
+// Copyright (c) 2018 Cranky Kernel
+//
+// Permission is hereby granted, free of charge, to any person
+// obtaining a copy of this software and associated documentation
+// files (the "Software"), to deal in the Software without
+// restriction, including without limitation the rights to use, copy,
+// modify, merge, publish, distribute, sublicense, and/or sell copies
+// of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be
+// included in all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND,
+// EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF
+// MERCHANTABILITY, FITNESS FOR A PARTICULAR PURPOSE AND
+// NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT HOLDERS
+// BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER IN AN
+// ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN
+// CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package binance
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/gorilla/websocket"
+)
+
+// Websocket request methods for managing the streams of a connection.
+const (
+	StreamMethodSubscribe         = "SUBSCRIBE"
+	StreamMethodUnsubscribe       = "UNSUBSCRIBE"
+	StreamMethodListSubscriptions = "LIST_SUBSCRIPTIONS"
+)
+
+// How long to wait for the response to a websocket request.
+const streamRequestTimeout = 10 * time.Second
+
+type streamRequest struct {
+	Method string   `json:"method"`
+	Params []string `json:"params,omitempty"`
+	Id     int64    `json:"id"`
+}
+
+// StreamRequestError is an error response to a websocket request.
+type StreamRequestError struct {
+	Code int64  `json:"code"`
+	Msg  string `json:"msg"`
+}
+
+func (e *StreamRequestError) Error() string {
+	return fmt.Sprintf("stream request failed: code=%d; msg=%s", e.Code, e.Msg)
+}
+
+// streamResponse is the response to a websocket request. Binance has sent
+// errors both nested under "error" and at the top level.
+type streamResponse struct {
+	Id     *int64              `json:"id"`
+	Result json.RawMessage     `json:"result"`
+	Error  *StreamRequestError `json:"error"`
+	Code   *int64              `json:"code"`
+	Msg    string              `json:"msg"`
+
+	err error
+}
+
+func (r streamResponse) error() error {
+	if r.err != nil {
+		return r.err
+	}
+	if r.Error != nil {
+		return r.Error
+	}
+	if r.Code != nil {
+		return &StreamRequestError{Code: *r.Code, Msg: r.Msg}
+	}
+	return nil
+}
+
+var errStreamNotConnected = fmt.Errorf("stream not connected")
+
+// Subscribe adds streams to the stream set. If connected the streams are
+// subscribed to on the live connection, returning once Binance has
+// acknowledged the request; otherwise they are included when the
+// connection is next made. Not supported with the single stream endpoint.
+func (m *ManagedStream) Subscribe(ctx context.Context, streams ...string) error {
+	if m.Single {
+		return fmt.Errorf("subscribe is not supported on the single stream endpoint")
+	}
+
+	// The streams are added before the request is sent so a reconnect
+	// while waiting for the response includes them.
+	m.lock.Lock()
+	added := []string{}
+	for _, stream := range streams {
+		if !containsString(m.streams, stream) && !containsString(added, stream) {
+			added = append(added, stream)
+		}
+	}
+	m.streams = append(m.streams, added...)
+	m.lock.Unlock()
+
+	_, err := m.request(ctx, StreamMethodSubscribe, streams)
+	if err != nil && err != errStreamNotConnected {
+		m.lock.Lock()
+		remaining := []string{}
+		for _, stream := range m.streams {
+			if !containsString(added, stream) {
+				remaining = append(remaining, stream)
+			}
+		}
+		m.streams = remaining
+		m.lock.Unlock()
+		return err
+	}
+	return nil
+}
+
+// Unsubscribe removes streams from the stream set, unsubscribing from them
+// on the live connection if connected.
+func (m *ManagedStream) Unsubscribe(ctx context.Context, streams ...string) error {
+	if m.Single {
+		return fmt.Errorf("unsubscribe is not supported on the single stream endpoint")
+	}
+	_, err := m.request(ctx, StreamMethodUnsubscribe, streams)
+	if err != nil && err != errStreamNotConnected {
+		return err
+	}
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	remaining := []string{}
+	for _, stream := range m.streams {
+		if !containsString(streams, stream) {
+			remaining = append(remaining, stream)
+		}
+	}
+	m.streams = remaining
+	return nil
+}
+
+// ListSubscriptions asks Binance for the streams subscribed to on the live
+// connection. Not supported with the single stream endpoint.
+func (m *ManagedStream) ListSubscriptions(ctx context.Context) ([]string, error) {
+	if m.Single {
+		return nil, fmt.Errorf("list subscriptions is not supported on the single stream endpoint")
+	}
+	result, err := m.request(ctx, StreamMethodListSubscriptions, nil)
+	if err != nil {
+		return nil, err
+	}
+	var streams []string
+	if err := json.Unmarshal(result, &streams); err != nil {
+		return nil, err
+	}
+	return streams, nil
+}
+
+// request sends a request on the live connection and waits for the
+// response with the same ID.
+func (m *ManagedStream) request(ctx context.Context, method string, params []string) (json.RawMessage, error) {
+	m.lock.Lock()
+	ws := m.ws
+	if ws == nil {
+		m.lock.Unlock()
+		return nil, errStreamNotConnected
+	}
+	m.nextId++
+	id := m.nextId
+	responseChannel := make(chan streamResponse, 1)
+	m.pending[id] = responseChannel
+	m.lock.Unlock()
+
+	defer func() {
+		m.lock.Lock()
+		delete(m.pending, id)
+		m.lock.Unlock()
+	}()
+
+	m.writeLock.Lock()
+	err := ws.WriteJSON(streamRequest{
+		Method: method,
+		Params: params,
+		Id:     id,
+	})
+	m.writeLock.Unlock()
+	if err != nil {
+		return nil, err
+	}
+
+	timer := time.NewTimer(streamRequestTimeout)
+	defer timer.Stop()
+	select {
+	case response := <-responseChannel:
+		if err := response.error(); err != nil {
+			return nil, err
+		}
+		return response.Result, nil
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	case <-timer.C:
+		return nil, fmt.Errorf("timeout waiting for %s response", method)
+	}
+}
+
+// handleResponse passes a message that is a response to a request to the
+// waiting request, returning false if the message is not a response.
+func (m *ManagedStream) handleResponse(body []byte) bool {
+	// Stream data is either wrapped in {"stream":...} or has no "id".
+	if bytes.HasPrefix(body, []byte(`{"stream"`)) || !bytes.Contains(body, []byte(`"id"`)) {
+		return false
+	}
+	var response streamResponse
+	if err := json.Unmarshal(body, &response); err != nil || response.Id == nil {
+		return false
+	}
+	m.lock.Lock()
+	responseChannel, ok := m.pending[*response.Id]
+	m.lock.Unlock()
+	if ok {
+		select {
+		case responseChannel <- response:
+		default:
+		}
+	}
+	return true
+}
+
+// setConn sets the live connection. When the connection is lost any
+// requests waiting for a response fail.
+func (m *ManagedStream) setConn(ws *websocket.Conn) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	m.ws = ws
+	if ws == nil {
+		for id, responseChannel := range m.pending {
+			select {
+			case responseChannel <- streamResponse{
+				err: fmt.Errorf("stream disconnected"),
+			}:
+			default:
+			}
+			delete(m.pending, id)
+		}
+	}
+}
+
+// resubscribe brings the live connection, made with streams, up to date
+// with the stream set, which may have changed while connecting. A failure
+// is left for the next connection to correct.
+func (m *ManagedStream) resubscribe(ctx context.Context, streams []string) {
+	if m.Single {
+		return
+	}
+	current := m.Streams()
+	added := []string{}
+	for _, stream := range current {
+		if !containsString(streams, stream) {
+			added = append(added, stream)
+		}
+	}
+	removed := []string{}
+	for _, stream := range streams {
+		if !containsString(current, stream) {
+			removed = append(removed, stream)
+		}
+	}
+	if len(added) > 0 {
+		m.request(ctx, StreamMethodSubscribe, added)
+	}
+	if len(removed) > 0 {
+		m.request(ctx, StreamMethodUnsubscribe, removed)
+	}
+}
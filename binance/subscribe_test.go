@@ -0,0 +1,160 @@
+package binance
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/gorilla/websocket"
+)
+
+func TestManagedStreamSubscribe(t *testing.T) {
+	upgrader := websocket.Upgrader{}
+	lock := sync.Mutex{}
+	paths := []string{}
+	drop := make(chan bool, 1)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ws, err := upgrader.Upgrade(w, r, nil)
+		if err != nil {
+			return
+		}
+		defer ws.Close()
+		lock.Lock()
+		paths = append(paths, r.URL.String())
+		lock.Unlock()
+
+		subscriptions := []string{}
+		if value := r.URL.Query().Get("streams"); value != "" {
+			subscriptions = strings.Split(value, "/")
+		}
+		go func() {
+			<-drop
+			ws.Close()
+		}()
+		for {
+			var request streamRequest
+			if err := ws.ReadJSON(&request); err != nil {
+				return
+			}
+			response := map[string]interface{}{"id": request.Id, "result": nil}
+			switch request.Method {
+			case StreamMethodSubscribe:
+				if containsString(request.Params, "bad") {
+					response = map[string]interface{}{"id": request.Id,
+						"error": map[string]interface{}{"code": 2, "msg": "Invalid request"}}
+					break
+				}
+				// Like Binance, subscribing again is not an error.
+				for _, stream := range request.Params {
+					if !containsString(subscriptions, stream) {
+						subscriptions = append(subscriptions, stream)
+					}
+				}
+				// Some data for the new stream arrives before the response.
+				ws.WriteJSON(map[string]interface{}{"stream": request.Params[0], "data": map[string]interface{}{"id": 1}})
+			case StreamMethodUnsubscribe:
+				remaining := []string{}
+				for _, stream := range subscriptions {
+					if !containsString(request.Params, stream) {
+						remaining = append(remaining, stream)
+					}
+				}
+				subscriptions = remaining
+			case StreamMethodListSubscriptions:
+				response["result"] = subscriptions
+			}
+			ws.WriteJSON(response)
+		}
+	}))
+	defer server.Close()
+
+	stream := NewManagedStream([]string{"ethbtc@trade"},
+		WithStreamUrl("ws"+strings.TrimPrefix(server.URL, "http")))
+	stream.MinBackoff = time.Millisecond
+	stream.MaxBackoff = time.Millisecond
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	channel := make(chan ManagedStreamEvent)
+	go stream.Run(ctx, channel)
+
+	events := make(chan ManagedStreamEventType, 100)
+	messages := make(chan []byte, 100)
+	go func() {
+		for event := range channel {
+			if event.Type == ManagedStreamMessage {
+				messages <- event.Message
+			} else {
+				events <- event.Type
+			}
+		}
+	}()
+	expectEvent := func(eventType ManagedStreamEventType) {
+		select {
+		case event := <-events:
+			if event != eventType {
+				t.Fatalf("expected %s, got %s", eventType, event)
+			}
+		case <-time.After(5 * time.Second):
+			t.Fatalf("timeout waiting for %s", eventType)
+		}
+	}
+
+	expectEvent(ManagedStreamConnected)
+	if err := stream.Subscribe(ctx, "bnbbtc@trade"); err != nil {
+		t.Fatal(err)
+	}
+	var message CombinedStreamUnknown
+	if err := json.Unmarshal(<-messages, &message); err != nil || message.Stream != "bnbbtc@trade" {
+		t.Errorf("unexpected message: %+v %v", message, err)
+	}
+
+	err := stream.Subscribe(ctx, "bad")
+	if _, ok := err.(*StreamRequestError); !ok {
+		t.Errorf("expected a *StreamRequestError, got %v", err)
+	}
+	if err := stream.Unsubscribe(ctx, "ethbtc@trade"); err != nil {
+		t.Fatal(err)
+	}
+
+	subscriptions, err := stream.ListSubscriptions(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(subscriptions) != 1 || subscriptions[0] != "bnbbtc@trade" {
+		t.Errorf("unexpected subscriptions: %v", subscriptions)
+	}
+	if streams := stream.Streams(); len(streams) != 1 || streams[0] != "bnbbtc@trade" {
+		t.Errorf("unexpected stream set: %v", streams)
+	}
+
+	// The reconnect restores the current subscriptions.
+	drop <- true
+	expectEvent(ManagedStreamDisconnected)
+	expectEvent(ManagedStreamReconnected)
+	lock.Lock()
+	defer lock.Unlock()
+	if len(paths) != 2 || paths[1] != "/stream?streams=bnbbtc@trade" {
+		t.Errorf("unexpected paths: %v", paths)
+	}
+}
+
+func TestManagedStreamSingleRequests(t *testing.T) {
+	stream := NewManagedStream([]string{"ethbtc@trade"})
+	stream.Single = true
+	ctx := context.Background()
+	if err := stream.Subscribe(ctx, "bnbbtc@trade"); err == nil {
+		t.Errorf("expected subscribe to fail")
+	}
+	if err := stream.Unsubscribe(ctx, "ethbtc@trade"); err == nil {
+		t.Errorf("expected unsubscribe to fail")
+	}
+	if _, err := stream.ListSubscriptions(ctx); err == nil {
+		t.Errorf("expected list subscriptions to fail")
+	}
+}
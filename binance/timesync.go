@@ -0,0 +1,167 @@
+// The MIT License (MIT)
+//
+// Copyright (c) 2018 Cranky Kernel
+//
+// Permission is hereby granted, free of charge, to any person
+// obtaining a copy of this software and associated documentation
+// files (the "Software"), to deal in the Software without
+// restriction, including without limitation the rights to use, copy,
+// modify, merge, publish, distribute, sublicense, and/or sell copies
+// of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be
+// included in all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND,
+// EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF
+// MERCHANTABILITY, FITNESS FOR A PARTICULAR PURPOSE AND
+// NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT HOLDERS
+// BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER IN AN
+// ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN
+// CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package binance
+
+import (
+	"bytes"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"sync"
+	"time"
+
+	"gitlab.com/crankykernel/cryptotrader/util"
+)
+
+// The default interval between time syncs.
+const DEFAULT_TIME_SYNC_INTERVAL = 10 * time.Minute
+
+// How much weight a new sample is given when updating the offset.
+const timeSyncSmoothing = 0.25
+
+// The time sync shared by all clients that are not given their own.
+var defaultTimeSync = NewTimeSync()
+
+type ServerTimeResponse struct {
+	ServerTimeMillis int64 `json:"serverTime"`
+}
+
+// GetServerTime returns the server time. An unsigned request is used as
+// signed requests depend on the server time.
+func (c *RestClient) GetServerTime() (time.Time, error) {
+	httpResponse, err := c.Get("/api/v3/time", nil)
+	if err != nil {
+		return time.Time{}, err
+	}
+	defer httpResponse.Body.Close()
+	if httpResponse.StatusCode != http.StatusOK {
+		return time.Time{}, NewRestApiErrorFromResponse(httpResponse)
+	}
+	var response ServerTimeResponse
+	if err := json.NewDecoder(httpResponse.Body).Decode(&response); err != nil {
+		return time.Time{}, err
+	}
+	return util.MillisToTime(response.ServerTimeMillis), nil
+}
+
+// TimeSync keeps a smoothed estimate of the offset between the local clock
+// and the Binance server clock, which is applied to the timestamp of signed
+// requests.
+type TimeSync struct {
+	lock     sync.Mutex
+	offset   time.Duration
+	synced   bool
+	lastSync time.Time
+	interval time.Duration
+}
+
+func NewTimeSync() *TimeSync {
+	return &TimeSync{
+		interval: DEFAULT_TIME_SYNC_INTERVAL,
+	}
+}
+
+// SetInterval sets how often the offset is re-synced. An interval of 0 only
+// syncs once and then after an invalid timestamp error.
+func (s *TimeSync) SetInterval(interval time.Duration) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	s.interval = interval
+}
+
+// Offset returns the current estimate of server time minus local time.
+func (s *TimeSync) Offset() time.Duration {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	return s.offset
+}
+
+// Now returns the current time estimated for the server clock.
+func (s *TimeSync) Now() time.Time {
+	return time.Now().Add(s.Offset())
+}
+
+// Sync samples the server time and updates the offset. The server time is
+// assumed to be taken half way through the round trip.
+func (s *TimeSync) Sync(client *RestClient) error {
+	start := time.Now()
+	serverTime, err := client.GetServerTime()
+	if err != nil {
+		return err
+	}
+	end := time.Now()
+	sample := serverTime.Sub(start.Add(end.Sub(start) / 2))
+
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	if s.synced {
+		s.offset += time.Duration(float64(sample-s.offset) * timeSyncSmoothing)
+	} else {
+		s.offset = sample
+		s.synced = true
+	}
+	s.lastSync = end
+	return nil
+}
+
+// Resync discards the current offset and syncs again.
+func (s *TimeSync) Resync(client *RestClient) error {
+	s.lock.Lock()
+	s.synced = false
+	s.lock.Unlock()
+	return s.Sync(client)
+}
+
+func (s *TimeSync) due() bool {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	if !s.synced {
+		return true
+	}
+	return s.interval > 0 && time.Since(s.lastSync) > s.interval
+}
+
+// syncIfDue syncs if never synced or the interval has passed. Failure to
+// sync is not fatal, the current offset continues to be used.
+func (s *TimeSync) syncIfDue(client *RestClient) {
+	if s.due() {
+		s.Sync(client)
+	}
+}
+
+// isInvalidTimestampResponse checks if a response is the invalid timestamp
+// error. The body is buffered so it can still be read by the caller.
+func isInvalidTimestampResponse(response *http.Response) bool {
+	if response.StatusCode != http.StatusBadRequest {
+		return false
+	}
+	body, err := ioutil.ReadAll(response.Body)
+	response.Body.Close()
+	response.Body = ioutil.NopCloser(bytes.NewReader(body))
+	if err != nil {
+		return false
+	}
+	return newRestApiError(response.StatusCode, body).Code == ErrorCodeInvalidTimestamp
+}
@@ -0,0 +1,59 @@
+package binance
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+	"time"
+
+	"gitlab.com/crankykernel/cryptotrader/util"
+)
+
+func TestTimeSyncResyncOnInvalidTimestamp(t *testing.T) {
+	skew := time.Hour
+	accountRequests := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		serverTime := time.Now().Add(skew)
+		switch r.URL.Path {
+		case "/api/v3/time":
+			fmt.Fprintf(w, `{"serverTime":%d}`, util.TimeToMillis(serverTime))
+		case "/api/v3/account":
+			accountRequests++
+			timestamp, _ := strconv.ParseInt(r.URL.Query().Get("timestamp"), 10, 64)
+			if diff := util.TimeToMillis(serverTime) - timestamp; diff > 1000 || diff < -1000 {
+				w.WriteHeader(http.StatusBadRequest)
+				fmt.Fprint(w, `{"code":-1021,"msg":"Timestamp for this request is outside of the recvWindow."}`)
+				return
+			}
+			fmt.Fprint(w, `{}`)
+		}
+	}))
+	defer server.Close()
+
+	timeSync := NewTimeSync()
+	client := NewAuthenticatedClient("key", "secret",
+		WithRestUrl(server.URL),
+		WithTimeSync(timeSync),
+		WithRateLimiter(nil))
+
+	// The first request syncs.
+	if _, err := client.GetAccount(); err != nil {
+		t.Fatal(err)
+	}
+	if offset := timeSync.Offset(); offset < skew-time.Second || offset > skew+time.Second {
+		t.Fatalf("unexpected offset: %v", offset)
+	}
+
+	// Change the server clock, the next request should fail, resync and
+	// be retried.
+	skew = -time.Hour
+	accountRequests = 0
+	if _, err := client.GetAccount(); err != nil {
+		t.Fatal(err)
+	}
+	if accountRequests != 2 {
+		t.Errorf("expected 2 account requests, got %d", accountRequests)
+	}
+}
@@ -0,0 +1,228 @@
+// The MIT License (MIT)
+//
+// Copyright (c) 2018 Cranky Kernel
+//
+// Permission is hereby granted, free of charge, to any person
+// obtaining a copy of this software and associated documentation
+// files (the "Software"), to deal in the Software without
+// restriction, including without limitation the rights to use, copy,
+// modify, merge, publish, distribute, sublicense, and/or sell copies
+// of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be
+// included in all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND,
+// EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF
+// MERCHANTABILITY, FITNESS FOR A PARTICULAR PURPOSE AND
+// NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT HOLDERS
+// BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER IN AN
+// ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN
+// CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package binance
+
+import (
+	"sort"
+	"time"
+
+	"gitlab.com/crankykernel/cryptotrader/util"
+)
+
+// The maximum number of trades returned by a single myTrades request.
+const MY_TRADES_MAX_LIMIT = 1000
+
+type MyTradesParameters struct {
+	Symbol string
+
+	// Return trades with a trade ID greater than or equal to FromId. Only
+	// sent if it is not negative.
+	FromId int64
+
+	// If both are set the range may be at most 24 hours.
+	StartTime time.Time
+	EndTime   time.Time
+
+	Limit int64
+}
+
+// GetMyTrades returns a page of the account's trades for a symbol. Without
+// FromId or a time range the most recent trades are returned.
+func (c *RestClient) GetMyTrades(query MyTradesParameters) ([]TradeResponse, error) {
+	params := map[string]interface{}{
+		"symbol": query.Symbol,
+	}
+	if query.FromId > -1 {
+		params["fromId"] = query.FromId
+	}
+	if !query.StartTime.IsZero() {
+		params["startTime"] = util.TimeToMillis(query.StartTime)
+	}
+	if !query.EndTime.IsZero() {
+		params["endTime"] = util.TimeToMillis(query.EndTime)
+	}
+	if query.Limit > 0 {
+		params["limit"] = query.Limit
+	}
+	var response []TradeResponse
+	err := c.genericGetWithAuthAndDecode("/api/v3/myTrades", params, &response)
+	for i := range response {
+		if response[i].Symbol == "" {
+			response[i].Symbol = query.Symbol
+		}
+	}
+	return response, err
+}
+
+// MyTradesIterator pages through the account's trades for one or more
+// symbols, one symbol at a time in trade ID order. Use it like a
+// bufio.Scanner:
+//
+//	trades := client.IterateMyTrades(symbols, start, end)
+//	for trades.Next() {
+//		trade := trades.Trade()
+//	}
+//	if err := trades.Err(); err != nil {
+//	}
+type MyTradesIterator struct {
+	client  *RestClient
+	symbols []string
+	start   time.Time
+	end     time.Time
+
+	index     int
+	fromId    int64
+	exhausted bool
+	page      []TradeResponse
+	trade     TradeResponse
+	err       error
+}
+
+// IterateMyTrades returns an iterator over the account's trades for symbols
+// made between start and end. A zero start or end leaves that end of the
+// range open.
+//
+// The myTrades endpoint only allows time ranges of up to 24 hours, so the
+// first page of each symbol is requested by start time alone, or from the
+// first trade if there is no start, and the following pages by trade ID.
+// Paging a symbol stops at the first trade after the end.
+func (c *RestClient) IterateMyTrades(symbols []string, start time.Time, end time.Time) *MyTradesIterator {
+	return &MyTradesIterator{
+		client:  c,
+		symbols: symbols,
+		start:   start,
+		end:     end,
+		fromId:  -1,
+	}
+}
+
+// Next advances to the next trade, returning false when there are no more
+// trades or an error occurred.
+func (it *MyTradesIterator) Next() bool {
+	for it.err == nil {
+		if len(it.page) > 0 {
+			trade := it.page[0]
+			it.page = it.page[1:]
+			timestamp := util.MillisToTime(trade.TimeMillis)
+			if !it.start.IsZero() && timestamp.Before(it.start) {
+				continue
+			}
+			if !it.end.IsZero() && timestamp.After(it.end) {
+				// Trades are in ID and so time order, the rest of
+				// this symbol's trades are after the end too.
+				it.page = nil
+				it.exhausted = true
+				continue
+			}
+			it.trade = trade
+			return true
+		}
+
+		if it.exhausted {
+			it.index++
+			it.fromId = -1
+			it.exhausted = false
+		}
+		if it.index >= len(it.symbols) {
+			return false
+		}
+
+		query := MyTradesParameters{
+			Symbol: it.symbols[it.index],
+			FromId: it.fromId,
+			Limit:  MY_TRADES_MAX_LIMIT,
+		}
+		if it.fromId < 0 {
+			// The first page of a symbol. A start time on its own
+			// is not limited to 24 hours.
+			if it.start.IsZero() {
+				query.FromId = 0
+			} else {
+				query.StartTime = it.start
+			}
+		}
+		page, err := it.client.GetMyTrades(query)
+		if err != nil {
+			it.err = err
+			return false
+		}
+		if len(page) < MY_TRADES_MAX_LIMIT {
+			it.exhausted = true
+		}
+		if len(page) > 0 {
+			it.fromId = page[len(page)-1].ID + 1
+		}
+		it.page = page
+	}
+	return false
+}
+
+// Trade returns the current trade.
+func (it *MyTradesIterator) Trade() TradeResponse {
+	return it.trade
+}
+
+// Symbol returns the symbol currently being iterated.
+func (it *MyTradesIterator) Symbol() string {
+	if it.index < len(it.symbols) {
+		return it.symbols[it.index]
+	}
+	return ""
+}
+
+// Err returns the error that stopped the iteration, if any.
+func (it *MyTradesIterator) Err() error {
+	return it.err
+}
+
+// GetAccountSymbols returns the symbols the account is likely to have
+// traded: every symbol, including those no longer trading, whose base or
+// quote asset has a balance. An asset that was traded and no longer has a
+// balance is only found through the asset it was traded against.
+func (c *RestClient) GetAccountSymbols() ([]string, error) {
+	account, err := c.GetAccount()
+	if err != nil {
+		return nil, err
+	}
+	assets := map[string]bool{}
+	for _, balance := range account.Balances {
+		if balance.Free.Sign() > 0 || balance.Locked.Sign() > 0 {
+			assets[balance.Asset] = true
+		}
+	}
+
+	exchangeInfo, err := c.GetExchangeInfo()
+	if err != nil {
+		return nil, err
+	}
+	symbols := []string{}
+	for _, symbol := range exchangeInfo.Symbols {
+		if assets[symbol.BaseAsset] || assets[symbol.QuoteAsset] {
+			symbols = append(symbols, symbol.Symbol)
+		}
+	}
+	sort.Strings(symbols)
+	return symbols, nil
+}
@@ -0,0 +1,93 @@
+package binance
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+	"time"
+
+	"gitlab.com/crankykernel/cryptotrader/util"
+)
+
+func TestMyTradesIterator(t *testing.T) {
+	// ETHBTC has trades 0 to 1499 one a minute, BNBBTC has none and
+	// LTCBTC has trades 0 to 9.
+	start := time.Date(2018, 5, 1, 0, 0, 0, 0, time.UTC)
+	tradeCounts := map[string]int64{"ETHBTC": 1500, "BNBBTC": 0, "LTCBTC": 10}
+
+	requests := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		query := r.URL.Query()
+		if query.Get("signature") == "" {
+			t.Errorf("expected a signed request")
+		}
+		symbol := query.Get("symbol")
+		limit, _ := strconv.ParseInt(query.Get("limit"), 10, 64)
+		fromId, _ := strconv.ParseInt(query.Get("fromId"), 10, 64)
+		if value := query.Get("startTime"); value != "" {
+			if query.Get("fromId") != "" || query.Get("endTime") != "" {
+				t.Errorf("unexpected query with startTime: %s", r.URL.RawQuery)
+			}
+			startTime, _ := strconv.ParseInt(value, 10, 64)
+			minutes := (startTime - util.TimeToMillis(start) + 59999) / 60000
+			if minutes > 0 {
+				fromId = minutes
+			}
+		}
+		trades := []TradeResponse{}
+		for id := fromId; id < tradeCounts[symbol] && int64(len(trades)) < limit; id++ {
+			trades = append(trades, TradeResponse{
+				Symbol:     symbol,
+				ID:         id,
+				TimeMillis: util.TimeToMillis(start.Add(time.Duration(id) * time.Minute)),
+			})
+		}
+		json.NewEncoder(w).Encode(trades)
+	}))
+	defer server.Close()
+
+	client := NewAuthenticatedClient("key", "secret",
+		WithRestUrl(server.URL),
+		WithTimeSync(nil),
+		WithRateLimiter(nil))
+
+	symbols := []string{"ETHBTC", "BNBBTC", "LTCBTC"}
+	counts := map[string]int64{}
+	trades := client.IterateMyTrades(symbols, time.Time{}, time.Time{})
+	for trades.Next() {
+		trade := trades.Trade()
+		if trade.ID != counts[trade.Symbol] {
+			t.Fatalf("unexpected trade %s %d", trade.Symbol, trade.ID)
+		}
+		counts[trade.Symbol]++
+	}
+	if err := trades.Err(); err != nil {
+		t.Fatal(err)
+	}
+	if counts["ETHBTC"] != 1500 || counts["LTCBTC"] != 10 || requests != 4 {
+		t.Errorf("unexpected counts %v in %d requests", counts, requests)
+	}
+
+	// A time range starts paging each symbol at the start time and stops
+	// after the end.
+	requests = 0
+	trades = client.IterateMyTrades([]string{"ETHBTC", "LTCBTC"},
+		start.Add(1100*time.Minute), start.Add(1199*time.Minute))
+	count := 0
+	for trades.Next() {
+		trade := trades.Trade()
+		if trade.Symbol != "ETHBTC" || trade.ID != 1100+int64(count) {
+			t.Fatalf("unexpected trade %s %d", trade.Symbol, trade.ID)
+		}
+		count++
+	}
+	if err := trades.Err(); err != nil {
+		t.Fatal(err)
+	}
+	if count != 100 || requests != 2 {
+		t.Errorf("expected 100 trades in 2 requests, got %d in %d", count, requests)
+	}
+}
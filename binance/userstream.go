@@ -24,10 +24,25 @@
 
 package binance
 
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"gitlab.com/crankykernel/cryptotrader/decimal"
+)
+
+// User data stream event types.
+const (
+	UserStreamEventAccountInfo     = "outboundAccountInfo"
+	UserStreamEventExecutionReport = "executionReport"
+	UserStreamEventListStatus      = "listStatus"
+)
+
 type StreamAccountInfoBalance struct {
-	Asset  string  `json:"a"`
-	Free   float64 `json:"f,string"`
-	Locked float64 `json:"l,string"`
+	Asset  string          `json:"a"`
+	Free   decimal.Decimal `json:"f"`
+	Locked decimal.Decimal `json:"l"`
 }
 
 type StreamOutboundAccountInfo struct {
@@ -45,31 +60,32 @@ type StreamOutboundAccountInfo struct {
 }
 
 type StreamExecutionReport struct {
-	EventType                string      `json:"e"`
-	EventTimeMillis          int64       `json:"E"`
-	Symbol                   string      `json:"s"`
-	ClientOrderID            string      `json:"c"`
-	Side                     OrderSide   `json:"S"`
-	OrderType                string      `json:"o"`
-	TimeInForce              string      `json:"f"`
-	Quantity                 float64     `json:"q,string"`
-	Price                    float64     `json:"p,string"`
-	StopPrice                float64     `json:"P,string"`
-	IcebergQuantity          float64     `json:"F,string"`
-	OriginalClientOrderID    string      `json:"C"`
-	CurrentExecutionType     OrderStatus `json:"x"`
-	CurrentOrderStatus       OrderStatus `json:"X"`
-	OrderRejectReason        string      `json:"r"`
-	OrderID                  int64       `json:"i"`
-	LastExecutedQuantity     float64     `json:"l,string"`
-	CumulativeFilledQuantity float64     `json:"z,string"`
-	LastExecutedPrice        float64     `json:"L,string"`
-	CommissionAmount         float64     `json:"n,string"`
-	CommissionAsset          string      `json:"N"`
-	TransactionTimeMillis    int64       `json:"T"`
-	TradeID                  int64       `json:"t"`
-	IsWorking                bool        `json:"w"`
-	IsMaker                  bool        `json:"m"`
+	EventType                string          `json:"e"`
+	EventTimeMillis          int64           `json:"E"`
+	Symbol                   string          `json:"s"`
+	ClientOrderID            string          `json:"c"`
+	Side                     OrderSide       `json:"S"`
+	OrderType                string          `json:"o"`
+	TimeInForce              string          `json:"f"`
+	Quantity                 decimal.Decimal `json:"q"`
+	Price                    decimal.Decimal `json:"p"`
+	StopPrice                decimal.Decimal `json:"P"`
+	IcebergQuantity          decimal.Decimal `json:"F"`
+	OriginalClientOrderID    string          `json:"C"`
+	CurrentExecutionType     OrderStatus     `json:"x"`
+	CurrentOrderStatus       OrderStatus     `json:"X"`
+	OrderRejectReason        string          `json:"r"`
+	OrderID                  int64           `json:"i"`
+	OrderListID              int64           `json:"g"`
+	LastExecutedQuantity     decimal.Decimal `json:"l"`
+	CumulativeFilledQuantity decimal.Decimal `json:"z"`
+	LastExecutedPrice        decimal.Decimal `json:"L"`
+	CommissionAmount         decimal.Decimal `json:"n"`
+	CommissionAsset          string          `json:"N"`
+	TransactionTimeMillis    int64           `json:"T"`
+	TradeID                  int64           `json:"t"`
+	IsWorking                bool            `json:"w"`
+	IsMaker                  bool            `json:"m"`
 
 	// Ignore values that we have to include here due to the case insensitivity
 	// of the Go JSON unmarshaller.
@@ -77,14 +93,75 @@ type StreamExecutionReport struct {
 	Ignore1 interface{} `json:"I,-"`
 }
 
+type StreamListStatusOrder struct {
+	Symbol        string `json:"s"`
+	OrderID       int64  `json:"i"`
+	ClientOrderID string `json:"c"`
+}
+
+// StreamListStatus is sent on the user data stream when the status of an
+// order list, such as an OCO order, changes.
+type StreamListStatus struct {
+	EventType             string                  `json:"e"`
+	EventTimeMillis       int64                   `json:"E"`
+	Symbol                string                  `json:"s"`
+	OrderListID           int64                   `json:"g"`
+	ContingencyType       string                  `json:"c"`
+	ListStatusType        string                  `json:"l"`
+	ListOrderStatus       string                  `json:"L"`
+	ListRejectReason      string                  `json:"r"`
+	ListClientOrderID     string                  `json:"C"`
+	TransactionTimeMillis int64                   `json:"T"`
+	Orders                []StreamListStatusOrder `json:"O"`
+}
+
+// DecodeUserStreamEvent decodes a message from the user data stream into a
+// *StreamOutboundAccountInfo, *StreamExecutionReport or *StreamListStatus
+// depending on its event type.
+func DecodeUserStreamEvent(body []byte) (interface{}, error) {
+	// The event time must be declared as the decoder would otherwise match
+	// "E" to the event type, keys being matched case insensitively.
+	var header struct {
+		EventType       string `json:"e"`
+		EventTimeMillis int64  `json:"E"`
+	}
+	if err := json.Unmarshal(body, &header); err != nil {
+		return nil, err
+	}
+	var event interface{}
+	switch header.EventType {
+	case UserStreamEventAccountInfo:
+		event = &StreamOutboundAccountInfo{}
+	case UserStreamEventExecutionReport:
+		event = &StreamExecutionReport{}
+	case UserStreamEventListStatus:
+		event = &StreamListStatus{}
+	default:
+		return nil, fmt.Errorf("unknown user stream event type: %s", header.EventType)
+	}
+	if err := json.Unmarshal(body, event); err != nil {
+		return nil, err
+	}
+	return event, nil
+}
+
 func OpenUserStream(restClient *RestClient) (*StreamClient, error) {
-	listenKey, err := restClient.GetUserDataStream()
+	return OpenUserStreamContext(restClient.Context(), restClient)
+}
+
+// OpenUserStreamContext opens the user data stream, closing it when ctx is
+// done.
+func OpenUserStreamContext(ctx context.Context, restClient *RestClient) (*StreamClient, error) {
+	listenKey, err := restClient.WithContext(ctx).GetUserDataStream()
 	if err != nil {
 		return nil, err
 	}
 
-	streamClient := NewStreamClient()
-	if err := streamClient.ConnectSingle(listenKey); err != nil {
+	// Use the same endpoints and user agent as the REST client.
+	streamClient := &StreamClient{
+		config: restClient.config,
+	}
+	if err := streamClient.ConnectSingleContext(ctx, listenKey); err != nil {
 		return nil, err
 	}
 
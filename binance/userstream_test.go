@@ -13,3 +13,36 @@ func TestDecodeRawOrderUpdate(t *testing.T) {
 		t.Fatal(err)
 	}
 }
+
+func TestDecodeUserStreamEvent(t *testing.T) {
+	buf := `{"e":"listStatus","E":1564034571879,"s":"ETHBTC","g":2,"c":"OCO","l":"EXEC_STARTED","L":"EXECUTING","r":"NONE","C":"F4QN4G8DlFATFlIUQ0cjdD","T":1564034571876,"O":[{"s":"ETHBTC","i":17,"c":"AJYsMjErWJesZvqlJCTUgL"},{"s":"ETHBTC","i":18,"c":"bfYPSQdLoqAJeNrOr9adzq"}]}`
+	event, err := DecodeUserStreamEvent([]byte(buf))
+	if err != nil {
+		t.Fatal(err)
+	}
+	listStatus, ok := event.(*StreamListStatus)
+	if !ok {
+		t.Fatalf("expected *StreamListStatus, got %T", event)
+	}
+	if listStatus.OrderListID != 2 || listStatus.ListStatusType != ListStatusTypeExecStarted ||
+		listStatus.ListOrderStatus != ListOrderStatusExecuting ||
+		listStatus.ListClientOrderID != "F4QN4G8DlFATFlIUQ0cjdD" {
+		t.Errorf("unexpected list status: %+v", listStatus)
+	}
+	if len(listStatus.Orders) != 2 || listStatus.Orders[1].OrderID != 18 {
+		t.Errorf("unexpected list status orders: %+v", listStatus.Orders)
+	}
+
+	buf = `{"e":"executionReport","E":1525367516316,"s":"ETHBTC","c":"abc","S":"SELL","o":"LIMIT_MAKER","g":2,"i":17,"X":"NEW"}`
+	event, err = DecodeUserStreamEvent([]byte(buf))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if report, ok := event.(*StreamExecutionReport); !ok || report.OrderListID != 2 {
+		t.Errorf("unexpected execution report: %+v", event)
+	}
+
+	if _, err := DecodeUserStreamEvent([]byte(`{"e":"unknown","E":1}`)); err == nil {
+		t.Errorf("expected error for unknown event type")
+	}
+}
@@ -0,0 +1,419 @@
+// The MIT License (MIT)
+//
+// Copyright (c) 2018 Cranky Kernel
+//
+// Permission is hereby granted, free of charge, to any person
+// obtaining a copy of this software and associated documentation
+// files (the "Software"), to deal in the Software without
+// restriction, including without limitation the rights to use, copy,
+// modify, merge, publish, distribute, sublicense, and/or sell copies
+// of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be
+// included in all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND,
+// EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF
+// MERCHANTABILITY, FITNESS FOR A PARTICULAR PURPOSE AND
+// NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT HOLDERS
+// BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER IN AN
+// ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN
+// CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package binance
+
+import (
+	"fmt"
+	"strings"
+
+	"gitlab.com/crankykernel/cryptotrader/decimal"
+)
+
+const SymbolStatusTrading = "TRADING"
+
+// Symbol filter types.
+const (
+	FilterTypePrice         = "PRICE_FILTER"
+	FilterTypePercentPrice  = "PERCENT_PRICE"
+	FilterTypeLotSize       = "LOT_SIZE"
+	FilterTypeMarketLotSize = "MARKET_LOT_SIZE"
+	FilterTypeMinNotional   = "MIN_NOTIONAL"
+	FilterTypeMaxNumOrders  = "MAX_NUM_ORDERS"
+
+	FilterTypeMaxNumAlgoOrders = "MAX_NUM_ALGO_ORDERS"
+	FilterTypeIcebergParts     = "ICEBERG_PARTS"
+)
+
+// Checks that are not exchange filters but are reported as violations
+// alongside them.
+const (
+	checkSymbol    = "SYMBOL"
+	checkOrderType = "ORDER_TYPE"
+)
+
+// Filter returns the filter of the given type, if the symbol has one.
+func (s SymbolInfoResponse) Filter(filterType string) (SymbolFilterResponse, bool) {
+	for _, filter := range s.Filters {
+		if filter.FilterType == filterType {
+			return filter, true
+		}
+	}
+	return SymbolFilterResponse{}, false
+}
+
+// FilterViolation is a single reason an order would be rejected.
+type FilterViolation struct {
+	// The filter type, or SYMBOL or ORDER_TYPE.
+	Filter  string
+	Message string
+}
+
+func (v FilterViolation) String() string {
+	return fmt.Sprintf("%s: %s", v.Filter, v.Message)
+}
+
+// OrderValidationError is returned when an order fails validation against
+// the symbol filters. It is categorized as a filter failure.
+type OrderValidationError struct {
+	Symbol     string
+	Violations []FilterViolation
+}
+
+func (e *OrderValidationError) Error() string {
+	messages := []string{}
+	for _, violation := range e.Violations {
+		messages = append(messages, violation.String())
+	}
+	return fmt.Sprintf("order for %s failed validation: %s", e.Symbol,
+		strings.Join(messages, "; "))
+}
+
+// OrderAdjustment describes a change made to an order by Adjust.
+type OrderAdjustment struct {
+	// The field adjusted: price, stop price or quantity.
+	Field  string
+	Filter string
+	From   decimal.Decimal
+	To     decimal.Decimal
+	Reason string
+}
+
+func (a OrderAdjustment) String() string {
+	return fmt.Sprintf("%s adjusted from %s to %s: %s (%s)",
+		a.Field, a.From, a.To, a.Reason, a.Filter)
+}
+
+// OrderValidationContext holds market state some filters depend on. Checks
+// depending on a zero value are skipped.
+type OrderValidationContext struct {
+	// The average price as returned by GetAveragePrice. Used by
+	// PERCENT_PRICE and by MIN_NOTIONAL for market orders.
+	AveragePrice decimal.Decimal
+
+	// The number of open orders on the symbol, for MAX_NUM_ORDERS.
+	OpenOrders int64
+
+	// The number of open stop loss and take profit orders on the symbol,
+	// for MAX_NUM_ALGO_ORDERS.
+	OpenAlgoOrders int64
+}
+
+// OrderValidator checks orders against the symbol status, allowed order
+// types and filters found in the exchange info, so filter failures can be
+// caught before the order is sent.
+type OrderValidator struct {
+	symbols map[string]SymbolInfoResponse
+}
+
+func NewOrderValidator(exchangeInfo *ExchangeInfoResponse) *OrderValidator {
+	validator := &OrderValidator{
+		symbols: make(map[string]SymbolInfoResponse),
+	}
+	for _, symbol := range exchangeInfo.Symbols {
+		validator.symbols[symbol.Symbol] = symbol
+	}
+	return validator
+}
+
+func (v *OrderValidator) GetSymbol(symbol string) (SymbolInfoResponse, bool) {
+	info, ok := v.symbols[symbol]
+	return info, ok
+}
+
+// Validate returns an *OrderValidationError listing every check the order
+// fails, or nil if the order passes.
+func (v *OrderValidator) Validate(order OrderParameters, context OrderValidationContext) error {
+	violations := []FilterViolation{}
+	violate := func(filter string, format string, args ...interface{}) {
+		violations = append(violations, FilterViolation{
+			Filter:  filter,
+			Message: fmt.Sprintf(format, args...),
+		})
+	}
+
+	symbol, ok := v.symbols[order.Symbol]
+	if !ok {
+		violate(checkSymbol, "unknown symbol")
+		return &OrderValidationError{order.Symbol, violations}
+	}
+	if symbol.Status != SymbolStatusTrading {
+		violate(checkSymbol, "symbol status is %s", symbol.Status)
+	}
+	if len(symbol.OrderTypes) > 0 && !containsString(symbol.OrderTypes, string(order.Type)) {
+		violate(checkOrderType, "order type %s not allowed, allowed types are %s",
+			order.Type, strings.Join(symbol.OrderTypes, ", "))
+	}
+
+	checkPrice := func(name string, price decimal.Decimal) {
+		if price.Sign() <= 0 {
+			violate(FilterTypePrice, "%s must be greater than 0", name)
+			return
+		}
+		if filter, ok := symbol.Filter(FilterTypePrice); ok {
+			if !filter.MinPrice.IsZero() && price.LessThan(filter.MinPrice) {
+				violate(FilterTypePrice, "%s %s is less than the minimum price %s",
+					name, price, filter.MinPrice)
+			}
+			if !filter.MaxPrice.IsZero() && price.GreaterThan(filter.MaxPrice) {
+				violate(FilterTypePrice, "%s %s is greater than the maximum price %s",
+					name, price, filter.MaxPrice)
+			}
+			if !price.Sub(filter.MinPrice).IsMultipleOf(filter.TickSize) {
+				violate(FilterTypePrice, "%s %s is not a multiple of the tick size %s",
+					name, price, filter.TickSize.Normalize())
+			}
+		}
+		if filter, ok := symbol.Filter(FilterTypePercentPrice); ok && !context.AveragePrice.IsZero() {
+			up, down := percentPriceBounds(filter, context.AveragePrice)
+			if !filter.MultiplierUp.IsZero() && price.GreaterThan(up) {
+				violate(FilterTypePercentPrice, "%s %s is more than %s times the average price %s",
+					name, price, filter.MultiplierUp, context.AveragePrice)
+			}
+			if price.LessThan(down) {
+				violate(FilterTypePercentPrice, "%s %s is less than %s times the average price %s",
+					name, price, filter.MultiplierDown, context.AveragePrice)
+			}
+		}
+	}
+
+	hasPrice := orderTypeHasPrice(order.Type)
+	if hasPrice {
+		checkPrice("price", order.Price)
+	}
+	if orderTypeHasStopPrice(order.Type) {
+		checkPrice("stop price", order.StopPrice)
+	}
+
+	// A MARKET order may be for an amount of the quote asset instead of a
+	// quantity, in which case only MIN_NOTIONAL applies.
+	quoteOrder := order.Type == OrderTypeMarket && order.QuoteOrderQty.Sign() > 0
+
+	if quoteOrder {
+		if order.Quantity.Sign() != 0 {
+			violate(FilterTypeLotSize, "quantity and quote order quantity are exclusive")
+		}
+	} else if order.Quantity.Sign() <= 0 {
+		violate(FilterTypeLotSize, "quantity must be greater than 0")
+	} else {
+		lotFilters := []string{FilterTypeLotSize}
+		if order.Type == OrderTypeMarket {
+			lotFilters = append(lotFilters, FilterTypeMarketLotSize)
+		}
+		for _, filterType := range lotFilters {
+			filter, ok := symbol.Filter(filterType)
+			if !ok {
+				continue
+			}
+			if !filter.MinQty.IsZero() && order.Quantity.LessThan(filter.MinQty) {
+				violate(filterType, "quantity %s is less than the minimum quantity %s",
+					order.Quantity, filter.MinQty)
+			}
+			if !filter.MaxQty.IsZero() && order.Quantity.GreaterThan(filter.MaxQty) {
+				violate(filterType, "quantity %s is greater than the maximum quantity %s",
+					order.Quantity, filter.MaxQty)
+			}
+			if !order.Quantity.Sub(filter.MinQty).IsMultipleOf(filter.StepSize) {
+				violate(filterType, "quantity %s is not a multiple of the step size %s",
+					order.Quantity, filter.StepSize.Normalize())
+			}
+		}
+	}
+
+	if order.IcebergQty.Sign() > 0 {
+		if !symbol.IcebergAllowed {
+			violate(checkOrderType, "iceberg orders not allowed")
+		}
+		if filter, ok := symbol.Filter(FilterTypeLotSize); ok &&
+			!order.IcebergQty.Sub(filter.MinQty).IsMultipleOf(filter.StepSize) {
+			violate(FilterTypeLotSize, "iceberg quantity %s is not a multiple of the step size %s",
+				order.IcebergQty, filter.StepSize.Normalize())
+		}
+		if filter, ok := symbol.Filter(FilterTypeIcebergParts); ok && filter.Limit > 0 {
+			parts := order.Quantity.FloorToStep(order.IcebergQty).Div(order.IcebergQty, 0)
+			if !order.Quantity.IsMultipleOf(order.IcebergQty) {
+				parts = parts.Add(decimal.NewFromInt(1))
+			}
+			if parts.GreaterThan(decimal.NewFromInt(filter.Limit)) {
+				violate(FilterTypeIcebergParts, "order would be split into %s parts, the maximum is %d",
+					parts, filter.Limit)
+			}
+		}
+	}
+
+	if filter, ok := symbol.Filter(FilterTypeMinNotional); ok {
+		var notional decimal.Decimal
+		switch {
+		case quoteOrder:
+			if filter.ApplyToMarket {
+				notional = order.QuoteOrderQty
+			}
+		case hasPrice:
+			notional = order.Price.Mul(order.Quantity)
+		case filter.ApplyToMarket && orderTypeHasStopPrice(order.Type):
+			notional = order.StopPrice.Mul(order.Quantity)
+		case filter.ApplyToMarket:
+			notional = context.AveragePrice.Mul(order.Quantity)
+		}
+		if notional.Sign() > 0 && notional.LessThan(filter.MinNotional) {
+			violate(FilterTypeMinNotional, "notional %s is less than the minimum notional %s",
+				notional, filter.MinNotional)
+		}
+	}
+
+	if filter, ok := symbol.Filter(FilterTypeMaxNumAlgoOrders); ok &&
+		filter.MaxNumAlgoOrders > 0 && orderTypeHasStopPrice(order.Type) {
+		if context.OpenAlgoOrders >= filter.MaxNumAlgoOrders {
+			violate(FilterTypeMaxNumAlgoOrders, "%d open stop orders, the maximum is %d",
+				context.OpenAlgoOrders, filter.MaxNumAlgoOrders)
+		}
+	}
+
+	if filter, ok := symbol.Filter(FilterTypeMaxNumOrders); ok && filter.MaxNumOrders > 0 {
+		if context.OpenOrders >= filter.MaxNumOrders {
+			violate(FilterTypeMaxNumOrders, "%d open orders, the maximum is %d",
+				context.OpenOrders, filter.MaxNumOrders)
+		}
+	}
+
+	if len(violations) > 0 {
+		return &OrderValidationError{order.Symbol, violations}
+	}
+	return nil
+}
+
+// Adjust moves the price and stop price to the nearest valid price, and the
+// quantity down to the nearest valid quantity, clamping them to the filter
+// minimums and maximums. Each change is described by an OrderAdjustment.
+// The adjusted order is then validated; any remaining violations, such as
+// MIN_NOTIONAL which cannot be fixed without changing the size of the
+// order, are returned as an *OrderValidationError.
+func (v *OrderValidator) Adjust(order OrderParameters, context OrderValidationContext) (OrderParameters, []OrderAdjustment, error) {
+	adjustments := []OrderAdjustment{}
+	adjust := func(field string, filter string, from decimal.Decimal, to decimal.Decimal, reason string) {
+		if from.Cmp(to) != 0 {
+			adjustments = append(adjustments, OrderAdjustment{
+				Field:  field,
+				Filter: filter,
+				From:   from,
+				To:     to,
+				Reason: reason,
+			})
+		}
+	}
+
+	symbol, ok := v.symbols[order.Symbol]
+	if !ok {
+		return order, adjustments, v.Validate(order, context)
+	}
+
+	adjustPrice := func(field string, price decimal.Decimal) decimal.Decimal {
+		filter, hasPriceFilter := symbol.Filter(FilterTypePrice)
+		tickSize := filter.TickSize.Normalize()
+
+		if percentFilter, ok := symbol.Filter(FilterTypePercentPrice); ok && !context.AveragePrice.IsZero() {
+			up, down := percentPriceBounds(percentFilter, context.AveragePrice)
+			from := price
+			if !percentFilter.MultiplierUp.IsZero() && price.GreaterThan(up) {
+				price = stepFrom(up, filter.MinPrice, tickSize, decimal.Decimal.FloorToStep)
+				adjust(field, FilterTypePercentPrice, from, price,
+					fmt.Sprintf("lowered to within %s times the average price %s",
+						percentFilter.MultiplierUp, context.AveragePrice))
+			} else if price.LessThan(down) {
+				price = stepFrom(down, filter.MinPrice, tickSize, decimal.Decimal.CeilToStep)
+				adjust(field, FilterTypePercentPrice, from, price,
+					fmt.Sprintf("raised to within %s times the average price %s",
+						percentFilter.MultiplierDown, context.AveragePrice))
+			}
+		}
+
+		if hasPriceFilter {
+			from := price
+			if !filter.MinPrice.IsZero() && price.LessThan(filter.MinPrice) {
+				price = filter.MinPrice
+				adjust(field, FilterTypePrice, from, price, "raised to the minimum price")
+			} else if !filter.MaxPrice.IsZero() && price.GreaterThan(filter.MaxPrice) {
+				price = stepFrom(filter.MaxPrice, filter.MinPrice, tickSize, decimal.Decimal.FloorToStep)
+				adjust(field, FilterTypePrice, from, price, "lowered to the maximum price")
+			} else {
+				price = stepFrom(price, filter.MinPrice, tickSize, decimal.Decimal.RoundToStep)
+				adjust(field, FilterTypePrice, from, price,
+					fmt.Sprintf("rounded to the tick size %s", tickSize))
+			}
+		}
+		return price
+	}
+
+	if orderTypeHasPrice(order.Type) && order.Price.Sign() > 0 {
+		order.Price = adjustPrice("price", order.Price)
+	}
+	if orderTypeHasStopPrice(order.Type) && order.StopPrice.Sign() > 0 {
+		order.StopPrice = adjustPrice("stop price", order.StopPrice)
+	}
+
+	if order.Quantity.Sign() > 0 {
+		lotFilters := []string{FilterTypeLotSize}
+		if order.Type == OrderTypeMarket {
+			lotFilters = append(lotFilters, FilterTypeMarketLotSize)
+		}
+		for _, filterType := range lotFilters {
+			filter, ok := symbol.Filter(filterType)
+			if !ok {
+				continue
+			}
+			stepSize := filter.StepSize.Normalize()
+			quantity := order.Quantity
+			if !filter.MinQty.IsZero() && quantity.LessThan(filter.MinQty) {
+				quantity = filter.MinQty
+				adjust("quantity", filterType, order.Quantity, quantity, "raised to the minimum quantity")
+			} else if !filter.MaxQty.IsZero() && quantity.GreaterThan(filter.MaxQty) {
+				quantity = stepFrom(filter.MaxQty, filter.MinQty, stepSize, decimal.Decimal.FloorToStep)
+				adjust("quantity", filterType, order.Quantity, quantity, "lowered to the maximum quantity")
+			} else {
+				quantity = stepFrom(quantity, filter.MinQty, stepSize, decimal.Decimal.FloorToStep)
+				adjust("quantity", filterType, order.Quantity, quantity,
+					fmt.Sprintf("rounded down to the step size %s", stepSize))
+			}
+			order.Quantity = quantity
+		}
+	}
+
+	return order, adjustments, v.Validate(order, context)
+}
+
+// percentPriceBounds returns the highest and lowest prices allowed by a
+// PERCENT_PRICE filter.
+func percentPriceBounds(filter SymbolFilterResponse, averagePrice decimal.Decimal) (decimal.Decimal, decimal.Decimal) {
+	return averagePrice.Mul(filter.MultiplierUp), averagePrice.Mul(filter.MultiplierDown)
+}
+
+// stepFrom rounds value to a multiple of step counted from base, as the
+// price and lot size filters count steps from the minimum.
+func stepFrom(value decimal.Decimal, base decimal.Decimal, step decimal.Decimal,
+	round func(decimal.Decimal, decimal.Decimal) decimal.Decimal) decimal.Decimal {
+	if step.IsZero() {
+		return value
+	}
+	return round(value.Sub(base), step).Add(base).Normalize()
+}
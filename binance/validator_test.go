@@ -0,0 +1,171 @@
+package binance
+
+import (
+	"encoding/json"
+	"testing"
+
+	"gitlab.com/crankykernel/cryptotrader/decimal"
+)
+
+const testExchangeInfo = `{
+  "symbols": [
+    {
+      "symbol": "ETHBTC",
+      "status": "TRADING",
+      "baseAsset": "ETH",
+      "quoteAsset": "BTC",
+      "orderTypes": ["LIMIT", "LIMIT_MAKER", "MARKET"],
+      "filters": [
+        {"filterType": "PRICE_FILTER", "minPrice": "0.00000100", "maxPrice": "100000.00000000", "tickSize": "0.00000100"},
+        {"filterType": "PERCENT_PRICE", "multiplierUp": "5", "multiplierDown": "0.2", "avgPriceMins": 5},
+        {"filterType": "LOT_SIZE", "minQty": "0.00100000", "maxQty": "100000.00000000", "stepSize": "0.00100000"},
+        {"filterType": "MIN_NOTIONAL", "minNotional": "0.00010000", "applyToMarket": true, "avgPriceMins": 5},
+        {"filterType": "MARKET_LOT_SIZE", "minQty": "0.00000000", "maxQty": "1000.00000000", "stepSize": "0.00000000"},
+        {"filterType": "MAX_NUM_ORDERS", "maxNumOrders": 200}
+      ]
+    },
+    {
+      "symbol": "BNBBTC",
+      "status": "BREAK",
+      "orderTypes": ["LIMIT"],
+      "filters": []
+    }
+  ]
+}`
+
+func newTestValidator(t *testing.T) *OrderValidator {
+	var exchangeInfo ExchangeInfoResponse
+	if err := json.Unmarshal([]byte(testExchangeInfo), &exchangeInfo); err != nil {
+		t.Fatal(err)
+	}
+	return NewOrderValidator(&exchangeInfo)
+}
+
+func violatedFilters(err error) []string {
+	filters := []string{}
+	if err, ok := err.(*OrderValidationError); ok {
+		for _, violation := range err.Violations {
+			filters = append(filters, violation.Filter)
+		}
+	}
+	return filters
+}
+
+func TestOrderValidatorValidate(t *testing.T) {
+	validator := newTestValidator(t)
+	context := OrderValidationContext{
+		AveragePrice: decimal.MustParse("0.03"),
+	}
+
+	tests := []struct {
+		name    string
+		order   OrderParameters
+		context OrderValidationContext
+		filters []string
+	}{
+		{"valid limit", OrderParameters{Symbol: "ETHBTC", Type: OrderTypeLimit,
+			Price: decimal.MustParse("0.031"), Quantity: decimal.MustParse("1.5")},
+			context, []string{}},
+		{"unknown symbol", OrderParameters{Symbol: "XXXBTC", Type: OrderTypeLimit},
+			context, []string{"SYMBOL"}},
+		{"not trading", OrderParameters{Symbol: "BNBBTC", Type: OrderTypeMarket,
+			Quantity: decimal.MustParse("1")},
+			context, []string{"SYMBOL", "ORDER_TYPE"}},
+		{"tick and step", OrderParameters{Symbol: "ETHBTC", Type: OrderTypeLimit,
+			Price: decimal.MustParse("0.0310005"), Quantity: decimal.MustParse("1.0005")},
+			context, []string{FilterTypePrice, FilterTypeLotSize}},
+		{"percent price", OrderParameters{Symbol: "ETHBTC", Type: OrderTypeLimit,
+			Price: decimal.MustParse("0.2"), Quantity: decimal.MustParse("1")},
+			context, []string{FilterTypePercentPrice}},
+		{"percent price without average", OrderParameters{Symbol: "ETHBTC", Type: OrderTypeLimit,
+			Price: decimal.MustParse("0.2"), Quantity: decimal.MustParse("1")},
+			OrderValidationContext{}, []string{}},
+		{"min notional", OrderParameters{Symbol: "ETHBTC", Type: OrderTypeLimit,
+			Price: decimal.MustParse("0.03"), Quantity: decimal.MustParse("0.001")},
+			context, []string{FilterTypeMinNotional}},
+		{"market lot size", OrderParameters{Symbol: "ETHBTC", Type: OrderTypeMarket,
+			Quantity: decimal.MustParse("2000")},
+			context, []string{FilterTypeMarketLotSize}},
+		{"quote quantity market", OrderParameters{Symbol: "ETHBTC", Type: OrderTypeMarket,
+			QuoteOrderQty: decimal.MustParse("0.00005")},
+			context, []string{FilterTypeMinNotional}},
+		{"stop price tick", OrderParameters{Symbol: "ETHBTC", Type: OrderTypeStopLossLimit,
+			Price: decimal.MustParse("0.029"), StopPrice: decimal.MustParse("0.0300001"),
+			Quantity: decimal.MustParse("1")},
+			context, []string{"ORDER_TYPE", FilterTypePrice}},
+		{"max num orders", OrderParameters{Symbol: "ETHBTC", Type: OrderTypeLimit,
+			Price: decimal.MustParse("0.031"), Quantity: decimal.MustParse("1")},
+			OrderValidationContext{OpenOrders: 200}, []string{FilterTypeMaxNumOrders}},
+	}
+
+	for _, test := range tests {
+		err := validator.Validate(test.order, test.context)
+		filters := violatedFilters(err)
+		if len(filters) != len(test.filters) {
+			t.Errorf("%s: expected violations %v, got %v", test.name, test.filters, err)
+			continue
+		}
+		for i := range filters {
+			if filters[i] != test.filters[i] {
+				t.Errorf("%s: expected violations %v, got %v", test.name, test.filters, err)
+				break
+			}
+		}
+		if err != nil && !IsFilterFailure(err) {
+			t.Errorf("%s: expected a filter failure", test.name)
+		}
+	}
+}
+
+func TestOrderValidatorAdjust(t *testing.T) {
+	validator := newTestValidator(t)
+
+	order := OrderParameters{
+		Symbol:   "ETHBTC",
+		Type:     OrderTypeLimit,
+		Price:    decimal.MustParse("0.03100051"),
+		Quantity: decimal.MustParse("1.23456"),
+	}
+	adjusted, adjustments, err := validator.Adjust(order, OrderValidationContext{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if adjusted.Price.String() != "0.031001" {
+		t.Errorf("expected price 0.031001, got %s", adjusted.Price)
+	}
+	if adjusted.Quantity.String() != "1.234" {
+		t.Errorf("expected quantity 1.234, got %s", adjusted.Quantity)
+	}
+	if len(adjustments) != 2 {
+		t.Fatalf("expected 2 adjustments, got %v", adjustments)
+	}
+	if adjustments[0].Field != "price" || adjustments[1].Field != "quantity" {
+		t.Errorf("unexpected adjustments: %v", adjustments)
+	}
+
+	// A price above the PERCENT_PRICE limit is lowered to the limit.
+	order.Price = decimal.MustParse("1")
+	adjusted, adjustments, err = validator.Adjust(order, OrderValidationContext{
+		AveragePrice: decimal.MustParse("0.03"),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if adjusted.Price.String() != "0.15" {
+		t.Errorf("expected price 0.15, got %s", adjusted.Price)
+	}
+	if adjustments[0].Filter != FilterTypePercentPrice {
+		t.Errorf("expected a PERCENT_PRICE adjustment, got %v", adjustments[0])
+	}
+
+	// An order too small for MIN_NOTIONAL is not resized.
+	order.Price = decimal.MustParse("0.03")
+	order.Quantity = decimal.MustParse("0.0015")
+	adjusted, _, err = validator.Adjust(order, OrderValidationContext{})
+	if adjusted.Quantity.String() != "0.001" {
+		t.Errorf("expected quantity 0.001, got %s", adjusted.Quantity)
+	}
+	if filters := violatedFilters(err); len(filters) != 1 || filters[0] != FilterTypeMinNotional {
+		t.Errorf("expected a MIN_NOTIONAL violation, got %v", err)
+	}
+}
@@ -0,0 +1,53 @@
+// The MIT License (MIT)
+//
+// Copyright (c) 2018 Cranky Kernel
+//
+// Permission is hereby granted, free of charge, to any person
+// obtaining a copy of this software and associated documentation
+// files (the "Software"), to deal in the Software without
+// restriction, including without limitation the rights to use, copy,
+// modify, merge, publish, distribute, sublicense, and/or sell copies
+// of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be
+// included in all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND,
+// EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF
+// MERCHANTABILITY, FITNESS FOR A PARTICULAR PURPOSE AND
+// NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT HOLDERS
+// BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER IN AN
+// ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN
+// CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package cmd
+
+import (
+	"github.com/spf13/cobra"
+	cmdbinance "gitlab.com/crankykernel/cryptotrader/cmd/binance"
+)
+
+var binanceAggTradesCmd = &cobra.Command{
+	Use:   "aggtrades <symbol>",
+	Short: "Download historical aggregate trades",
+	Long: `Download the aggregate trades for a symbol between --from and --to, or
+starting at the trade ID given by --from-id.
+
+JSON output has one trade per line in the same form as the
+<symbol>@aggTrade stream.
+`,
+	Run: func(cmd *cobra.Command, args []string) {
+		cmdbinance.AggTradesCommand(cmd.Flags(), args)
+	},
+}
+
+func init() {
+	flags := binanceAggTradesCmd.Flags()
+	flags.String("from", "", "Download trades made at or after this time")
+	flags.String("to", "", "Download trades made up to this time (default now)")
+	flags.Int64("from-id", -1, "Download trades starting at this aggregate trade ID")
+	flags.String("format", "csv", "Output format (csv or json)")
+	binanceCmd.AddCommand(binanceAggTradesCmd)
+}
@@ -27,6 +27,7 @@ package cmd
 import (
 	"github.com/spf13/cobra"
 	"gitlab.com/crankykernel/cryptotrader/binance"
+	cmdbinance "gitlab.com/crankykernel/cryptotrader/cmd/binance"
 	"gitlab.com/crankykernel/cryptotrader/cmd/common"
 	"github.com/spf13/viper"
 )
@@ -39,9 +40,10 @@ var binanceGetCmd = &cobra.Command{
 		if auth {
 			client = binance.NewAuthenticatedClient(
 				viper.GetString("binance.api.key"),
-				viper.GetString("binance.api.secret"))
+				viper.GetString("binance.api.secret"),
+				cmdbinance.ClientOptions()...)
 		} else {
-			client = binance.NewAnonymousClient()
+			client = binance.NewAnonymousClient(cmdbinance.ClientOptions()...)
 		}
 
 		common.Get(client, args)
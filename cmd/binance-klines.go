@@ -0,0 +1,54 @@
+// The MIT License (MIT)
+//
+// Copyright (c) 2018 Cranky Kernel
+//
+// Permission is hereby granted, free of charge, to any person
+// obtaining a copy of this software and associated documentation
+// files (the "Software"), to deal in the Software without
+// restriction, including without limitation the rights to use, copy,
+// modify, merge, publish, distribute, sublicense, and/or sell copies
+// of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be
+// included in all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND,
+// EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF
+// MERCHANTABILITY, FITNESS FOR A PARTICULAR PURPOSE AND
+// NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT HOLDERS
+// BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER IN AN
+// ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN
+// CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package cmd
+
+import (
+	"github.com/spf13/cobra"
+	cmdbinance "gitlab.com/crankykernel/cryptotrader/cmd/binance"
+)
+
+var binanceKlinesCmd = &cobra.Command{
+	Use:   "klines <symbol> <interval>",
+	Short: "Download klines (candlesticks)",
+	Long: `Download the klines for a symbol and interval between --from and --to.
+
+Valid intervals: 1m, 3m, 5m, 15m, 30m, 1h, 2h, 4h, 6h, 8h, 12h, 1d, 3d, 1w, 1M
+
+When --output names a file that already holds klines, the download resumes
+after the last kline in the file.
+`,
+	Run: func(cmd *cobra.Command, args []string) {
+		cmdbinance.KlinesCommand(cmd.Flags(), args)
+	},
+}
+
+func init() {
+	flags := binanceKlinesCmd.Flags()
+	flags.String("from", "", "Download klines opening at or after this time")
+	flags.String("to", "", "Download klines opening up to this time (default now)")
+	flags.String("format", "csv", "Output format (csv or json)")
+	flags.StringP("output", "o", "", "Append to this file instead of writing to stdout")
+	binanceCmd.AddCommand(binanceKlinesCmd)
+}
@@ -0,0 +1,99 @@
+// The MIT License (MIT)
+//
+// Copyright (c) 2018 Cranky Kernel
+//
+// Permission is hereby granted, free of charge, to any person
+// obtaining a copy of this software and associated documentation
+// files (the "Software"), to deal in the Software without
+// restriction, including without limitation the rights to use, copy,
+// modify, merge, publish, distribute, sublicense, and/or sell copies
+// of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be
+// included in all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND,
+// EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF
+// MERCHANTABILITY, FITNESS FOR A PARTICULAR PURPOSE AND
+// NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT HOLDERS
+// BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER IN AN
+// ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN
+// CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package cmd
+
+import (
+	"github.com/spf13/cobra"
+	cmdbinance "gitlab.com/crankykernel/cryptotrader/cmd/binance"
+)
+
+var binanceOcoCmd = &cobra.Command{
+	Use:   "oco",
+	Short: "Place and inspect OCO orders",
+}
+
+var binanceOcoPlaceCmd = &cobra.Command{
+	Use:   "place <buy|sell> <symbol>",
+	Short: "Place an OCO order",
+	Long: `Place an OCO order: a limit maker order at --price and a stop
+order triggered at --stop-price. The stop order is a stop loss limit
+order if --stop-limit-price is given, otherwise a stop loss market order.`,
+	Run: func(cmd *cobra.Command, args []string) {
+		cmdbinance.OcoPlaceCommand(cmd.Flags(), args)
+	},
+}
+
+var binanceOcoGetCmd = &cobra.Command{
+	Use:   "get <order-list-id>",
+	Short: "Get an OCO order",
+	Run: func(cmd *cobra.Command, args []string) {
+		cmdbinance.OcoGetCommand(cmd.Flags(), args)
+	},
+}
+
+var binanceOcoCancelCmd = &cobra.Command{
+	Use:   "cancel <symbol> <order-list-id>",
+	Short: "Cancel an OCO order",
+	Run: func(cmd *cobra.Command, args []string) {
+		cmdbinance.OcoCancelCommand(cmd.Flags(), args)
+	},
+}
+
+var binanceOcoListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List open OCO orders",
+	Run: func(cmd *cobra.Command, args []string) {
+		cmdbinance.OcoListCommand(cmd.Flags())
+	},
+}
+
+func init() {
+	flags := binanceOcoPlaceCmd.Flags()
+	flags.String("quantity", "", "Quantity of both orders")
+	flags.String("price", "", "Price of the limit order")
+	flags.String("stop-price", "", "Price that triggers the stop order")
+	flags.String("stop-limit-price", "", "Limit price of the stop order")
+	flags.String("stop-limit-time-in-force", "GTC", "Time in force of the stop limit order")
+	flags.String("limit-iceberg-quantity", "", "Visible quantity of the limit order")
+	flags.String("stop-iceberg-quantity", "", "Visible quantity of the stop limit order")
+	flags.String("list-client-order-id", "", "Client ID of the order list")
+	flags.String("limit-client-order-id", "", "Client order ID of the limit order")
+	flags.String("stop-client-order-id", "", "Client order ID of the stop order")
+	flags.String("response-type", "FULL", "Response type (ACK, RESULT or FULL)")
+	flags.Bool("dry-run", false, "Validate the order and print it instead of placing it")
+	binanceOcoCmd.AddCommand(binanceOcoPlaceCmd)
+
+	binanceOcoGetCmd.Flags().Bool("client-id", false, "Order list ID is a list client order ID")
+	binanceOcoCmd.AddCommand(binanceOcoGetCmd)
+
+	binanceOcoCancelCmd.Flags().Bool("client-id", false, "Order list ID is a list client order ID")
+	binanceOcoCmd.AddCommand(binanceOcoCancelCmd)
+
+	binanceOcoListCmd.Flags().Bool("all", false, "List all OCO orders, not just open ones")
+	binanceOcoListCmd.Flags().Int64("limit", 0, "Maximum number of OCO orders to list with --all")
+	binanceOcoCmd.AddCommand(binanceOcoListCmd)
+
+	binanceCmd.AddCommand(binanceOcoCmd)
+}
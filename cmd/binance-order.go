@@ -0,0 +1,94 @@
+// The MIT License (MIT)
+//
+// Copyright (c) 2018 Cranky Kernel
+//
+// Permission is hereby granted, free of charge, to any person
+// obtaining a copy of this software and associated documentation
+// files (the "Software"), to deal in the Software without
+// restriction, including without limitation the rights to use, copy,
+// modify, merge, publish, distribute, sublicense, and/or sell copies
+// of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be
+// included in all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND,
+// EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF
+// MERCHANTABILITY, FITNESS FOR A PARTICULAR PURPOSE AND
+// NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT HOLDERS
+// BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER IN AN
+// ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN
+// CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package cmd
+
+import (
+	"github.com/spf13/cobra"
+	"gitlab.com/crankykernel/cryptotrader/binance"
+	cmdbinance "gitlab.com/crankykernel/cryptotrader/cmd/binance"
+)
+
+var binanceOrderCmd = &cobra.Command{
+	Use:   "order",
+	Short: "Place an order",
+}
+
+var binanceOrderTypes = []struct {
+	use       string
+	short     string
+	orderType binance.OrderType
+}{
+	{"limit", "Place a limit order", binance.OrderTypeLimit},
+	{"market", "Place a market order", binance.OrderTypeMarket},
+	{"stop-loss", "Place a stop loss market order", binance.OrderTypeStopLoss},
+	{"stop-loss-limit", "Place a stop loss limit order", binance.OrderTypeStopLossLimit},
+	{"take-profit", "Place a take profit market order", binance.OrderTypeTakeProfit},
+	{"take-profit-limit", "Place a take profit limit order", binance.OrderTypeTakeProfitLimit},
+	{"limit-maker", "Place a limit order that is rejected if it would take", binance.OrderTypeLimitMaker},
+}
+
+func init() {
+	for _, orderType := range binanceOrderTypes {
+		orderType := orderType
+		cmd := &cobra.Command{
+			Use:   orderType.use + " <buy|sell> <symbol>",
+			Short: orderType.short,
+			Run: func(cmd *cobra.Command, args []string) {
+				cmdbinance.OrderCommand(orderType.orderType, cmd.Flags(), args)
+			},
+		}
+
+		flags := cmd.Flags()
+		flags.String("quantity", "", "Quantity of the base asset")
+		if orderType.orderType == binance.OrderTypeMarket {
+			flags.String("quote-quantity", "",
+				"Quantity of the quote asset to spend or receive instead of --quantity")
+		}
+		switch orderType.orderType {
+		case binance.OrderTypeMarket, binance.OrderTypeStopLoss, binance.OrderTypeTakeProfit:
+		default:
+			flags.String("price", "", "Limit price")
+		}
+		switch orderType.orderType {
+		case binance.OrderTypeStopLoss, binance.OrderTypeStopLossLimit,
+			binance.OrderTypeTakeProfit, binance.OrderTypeTakeProfitLimit:
+			flags.String("stop-price", "", "Price that triggers the order")
+		}
+		switch orderType.orderType {
+		case binance.OrderTypeLimit, binance.OrderTypeStopLossLimit,
+			binance.OrderTypeTakeProfitLimit:
+			flags.String("time-in-force", "GTC", "Time in force (GTC, IOC or FOK)")
+			flags.String("iceberg-quantity", "", "Visible quantity of an iceberg order")
+		}
+		flags.String("client-order-id", "", "Client order ID")
+		flags.String("response-type", "FULL", "Response type (ACK, RESULT or FULL)")
+		flags.Bool("dry-run", false,
+			"Validate the order and send it to the test endpoint instead of placing it")
+
+		binanceOrderCmd.AddCommand(cmd)
+	}
+
+	binanceCmd.AddCommand(binanceOrderCmd)
+}
@@ -0,0 +1,60 @@
+// The MIT License (MIT)
+//
+// Copyright (c) 2018 Cranky Kernel
+//
+// Permission is hereby granted, free of charge, to any person
+// obtaining a copy of this software and associated documentation
+// files (the "Software"), to deal in the Software without
+// restriction, including without limitation the rights to use, copy,
+// modify, merge, publish, distribute, sublicense, and/or sell copies
+// of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be
+// included in all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND,
+// EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF
+// MERCHANTABILITY, FITNESS FOR A PARTICULAR PURPOSE AND
+// NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT HOLDERS
+// BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER IN AN
+// ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN
+// CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package cmd
+
+import (
+	"github.com/spf13/cobra"
+	cmdbinance "gitlab.com/crankykernel/cryptotrader/cmd/binance"
+)
+
+var binanceOrdersCmd = &cobra.Command{
+	Use:   "orders [symbol]",
+	Short: "List open orders, or with --all the order history of a symbol",
+	Run: func(cmd *cobra.Command, args []string) {
+		cmdbinance.OrdersCommand(cmd.Flags(), args)
+	},
+}
+
+var binanceCancelCmd = &cobra.Command{
+	Use:   "cancel <symbol> [order-id...]",
+	Short: "Cancel orders by order ID, or with --all every open order on a symbol",
+	Run: func(cmd *cobra.Command, args []string) {
+		cmdbinance.CancelCommand(cmd.Flags(), args)
+	},
+}
+
+func init() {
+	flags := binanceOrdersCmd.Flags()
+	flags.Bool("all", false, "List all orders for the symbol, not just open orders")
+	flags.String("start", "", "With --all, list orders from this time")
+	flags.String("end", "", "With --all, list orders up to this time")
+	flags.String("format", "table", "Output format (table, csv, tab or json)")
+	binanceCmd.AddCommand(binanceOrdersCmd)
+
+	flags = binanceCancelCmd.Flags()
+	flags.Bool("all", false, "Cancel all open orders on the symbol")
+	flags.String("format", "table", "Output format (table, csv, tab or json)")
+	binanceCmd.AddCommand(binanceCancelCmd)
+}
@@ -17,6 +17,7 @@ package cmd
 import (
 	"github.com/spf13/cobra"
 	"gitlab.com/crankykernel/cryptotrader/binance"
+	cmdbinance "gitlab.com/crankykernel/cryptotrader/cmd/binance"
 	"github.com/spf13/viper"
 	"gitlab.com/crankykernel/cryptotrader/cmd/common"
 )
@@ -29,9 +30,10 @@ var binancePostCmd = &cobra.Command{
 		if auth {
 			client = binance.NewAuthenticatedClient(
 				viper.GetString("binance.api.key"),
-				viper.GetString("binance.api.secret"))
+				viper.GetString("binance.api.secret"),
+				cmdbinance.ClientOptions()...)
 		} else {
-			client = binance.NewAnonymousClient()
+			client = binance.NewAnonymousClient(cmdbinance.ClientOptions()...)
 		}
 		common.Post(client, args)
 	},
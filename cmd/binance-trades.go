@@ -0,0 +1,59 @@
+// The MIT License (MIT)
+//
+// Copyright (c) 2018 Cranky Kernel
+//
+// Permission is hereby granted, free of charge, to any person
+// obtaining a copy of this software and associated documentation
+// files (the "Software"), to deal in the Software without
+// restriction, including without limitation the rights to use, copy,
+// modify, merge, publish, distribute, sublicense, and/or sell copies
+// of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be
+// included in all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND,
+// EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF
+// MERCHANTABILITY, FITNESS FOR A PARTICULAR PURPOSE AND
+// NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT HOLDERS
+// BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER IN AN
+// ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN
+// CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package cmd
+
+import (
+	"github.com/spf13/cobra"
+	cmdbinance "gitlab.com/crankykernel/cryptotrader/cmd/binance"
+)
+
+var binanceTradesCmd = &cobra.Command{
+	Use:   "trades [symbol...]",
+	Short: "Export trade history",
+	Long: `Print the trade history of the given symbols, oldest first.
+
+With --all the symbols are discovered from the account: every symbol whose
+base or quote asset has a balance is checked. This makes one or more
+requests per symbol so may take a while.
+
+Available output formats:
+  - table
+  - csv
+  - tab
+  - json
+`,
+	Run: func(cmd *cobra.Command, args []string) {
+		cmdbinance.TradesCommand(cmd.Flags(), args)
+	},
+}
+
+func init() {
+	flags := binanceTradesCmd.Flags()
+	flags.Bool("all", false, "Export trades for every symbol the account may have traded")
+	flags.String("start", "", "Export trades from this time")
+	flags.String("end", "", "Export trades up to this time")
+	flags.String("format", "table", "Output format (table, csv, tab or json)")
+	binanceCmd.AddCommand(binanceTradesCmd)
+}
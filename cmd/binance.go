@@ -21,5 +21,13 @@ func init() {
 	viper.BindPFlag("binance.api.secret", flags.Lookup("api-secret"))
 	viper.BindEnv("binance.api.secret", "BINANCE_API_SECRET")
 
+	flags.String("api-url", "", "Binance REST API base URL")
+	viper.BindPFlag("binance.api.url", flags.Lookup("api-url"))
+	viper.BindEnv("binance.api.url", "BINANCE_API_URL")
+
+	flags.String("stream-url", "", "Binance websocket stream base URL")
+	viper.BindPFlag("binance.stream.url", flags.Lookup("stream-url"))
+	viper.BindEnv("binance.stream.url", "BINANCE_STREAM_URL")
+
 	rootCmd.AddCommand(binanceCmd)
 }
@@ -0,0 +1,125 @@
+// The MIT License (MIT)
+//
+// Copyright (c) 2018 Cranky Kernel
+//
+// Permission is hereby granted, free of charge, to any person
+// obtaining a copy of this software and associated documentation
+// files (the "Software"), to deal in the Software without
+// restriction, including without limitation the rights to use, copy,
+// modify, merge, publish, distribute, sublicense, and/or sell copies
+// of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be
+// included in all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND,
+// EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF
+// MERCHANTABILITY, FITNESS FOR A PARTICULAR PURPOSE AND
+// NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT HOLDERS
+// BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER IN AN
+// ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN
+// CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package binance
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"log"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/spf13/pflag"
+	"gitlab.com/crankykernel/cryptotrader/binance"
+	"gitlab.com/crankykernel/cryptotrader/util"
+)
+
+var aggTradesCsvHeader = []string{
+	"trade_id",
+	"price",
+	"quantity",
+	"first_trade_id",
+	"last_trade_id",
+	"time",
+	"buyer_maker",
+}
+
+// AggTradesCommand downloads the aggregate trades for a symbol to stdout.
+func AggTradesCommand(flags *pflag.FlagSet, args []string) {
+	if len(args) != 1 {
+		log.Fatal("error: expected arguments: <symbol>")
+	}
+	symbol := strings.ToUpper(args[0])
+	format, _ := flags.GetString("format")
+	if format != "csv" && format != "json" {
+		log.Fatal("error: unknown format: ", format)
+	}
+	from := timeFlag(flags, "from")
+	to := timeFlag(flags, "to")
+	fromId, _ := flags.GetInt64("from-id")
+	if fromId > -1 && !from.IsZero() {
+		log.Fatal("error: --from and --from-id cannot be used together")
+	}
+
+	csvWriter := csv.NewWriter(os.Stdout)
+	if format == "csv" {
+		csvWriter.Write(aggTradesCsvHeader)
+		csvWriter.Flush()
+	}
+	encoder := json.NewEncoder(os.Stdout)
+
+	count := 0
+	fn := func(trades []binance.StreamAggTrade) error {
+		for _, trade := range trades {
+			if format == "json" {
+				if err := encoder.Encode(trade); err != nil {
+					return err
+				}
+			} else {
+				csvWriter.Write(aggTradeCsvRecord(trade))
+			}
+		}
+		csvWriter.Flush()
+		if err := csvWriter.Error(); err != nil {
+			return err
+		}
+		count += len(trades)
+		last := trades[len(trades)-1]
+		log.Printf("downloaded %d trades to %s", count,
+			last.Timestamp().UTC().Format(time.RFC3339))
+		return nil
+	}
+
+	// On an interrupt the download stops between pages, so the output
+	// only holds complete pages.
+	ctx := interruptContext()
+	client := binance.NewAnonymousClient(ClientOptions()...).WithContext(ctx)
+	var err error
+	if fromId > -1 {
+		err = client.DownloadAggTradesFromId(symbol, fromId, to, fn)
+	} else {
+		err = client.DownloadAggTrades(symbol, from, to, fn)
+	}
+	if err != nil {
+		if ctx.Err() != nil {
+			log.Fatalf("interrupted after %d trades", count)
+		}
+		log.Fatal("error: ", err)
+	}
+}
+
+func aggTradeCsvRecord(trade binance.StreamAggTrade) []string {
+	return []string{
+		strconv.FormatInt(trade.TradeID, 10),
+		trade.Price.String(),
+		trade.Quantity.String(),
+		strconv.FormatInt(trade.FirstTradeID, 10),
+		strconv.FormatInt(trade.LastTradeID, 10),
+		strconv.FormatInt(util.TimeToMillis(trade.Timestamp()), 10),
+		strconv.FormatBool(trade.BuyerMaker),
+	}
+}
@@ -0,0 +1,224 @@
+// The MIT License (MIT)
+//
+// Copyright (c) 2018 Cranky Kernel
+//
+// Permission is hereby granted, free of charge, to any person
+// obtaining a copy of this software and associated documentation
+// files (the "Software"), to deal in the Software without
+// restriction, including without limitation the rights to use, copy,
+// modify, merge, publish, distribute, sublicense, and/or sell copies
+// of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be
+// included in all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND,
+// EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF
+// MERCHANTABILITY, FITNESS FOR A PARTICULAR PURPOSE AND
+// NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT HOLDERS
+// BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER IN AN
+// ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN
+// CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package binance
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/spf13/pflag"
+	"gitlab.com/crankykernel/cryptotrader/binance"
+	"gitlab.com/crankykernel/cryptotrader/util"
+)
+
+var klinesCsvHeader = []string{
+	"open_time",
+	"open",
+	"high",
+	"low",
+	"close",
+	"volume",
+	"close_time",
+	"quote_volume",
+	"trades",
+	"taker_buy_base_volume",
+	"taker_buy_quote_volume",
+}
+
+// KlinesCommand downloads the klines for a symbol and interval. When
+// writing to a file that already holds klines the download resumes after
+// the last kline in the file.
+func KlinesCommand(flags *pflag.FlagSet, args []string) {
+	if len(args) != 2 {
+		log.Fatal("error: expected arguments: <symbol> <interval>")
+	}
+	symbol := strings.ToUpper(args[0])
+	interval, err := binance.ParseKlineInterval(args[1])
+	if err != nil {
+		log.Fatal("error: ", err)
+	}
+	format, _ := flags.GetString("format")
+	if format != "csv" && format != "json" {
+		log.Fatal("error: unknown format: ", format)
+	}
+	from := timeFlag(flags, "from")
+	to := timeFlag(flags, "to")
+
+	var output io.Writer = os.Stdout
+	writeHeader := format == "csv"
+	if filename, _ := flags.GetString("output"); filename != "" {
+		file, resumeFrom, empty, err := openKlinesFile(filename, format)
+		if err != nil {
+			log.Fatal("error: ", err)
+		}
+		defer file.Close()
+		output = file
+		writeHeader = writeHeader && empty
+		if !resumeFrom.IsZero() {
+			log.Printf("resuming from %s", resumeFrom.UTC().Format(time.RFC3339))
+			from = resumeFrom
+		}
+	}
+
+	csvWriter := csv.NewWriter(output)
+	if writeHeader {
+		csvWriter.Write(klinesCsvHeader)
+		csvWriter.Flush()
+	}
+
+	count := 0
+	// On an interrupt the download stops between pages, so the output
+	// only holds complete pages.
+	ctx := interruptContext()
+	client := binance.NewAnonymousClient(ClientOptions()...).WithContext(ctx)
+	err = client.DownloadKlines(symbol, interval, from, to, func(klines []binance.Kline) error {
+		for _, kline := range klines {
+			if format == "json" {
+				buf, err := json.Marshal(kline)
+				if err != nil {
+					return err
+				}
+				buf = append(buf, '\n')
+				if _, err := output.Write(buf); err != nil {
+					return err
+				}
+			} else {
+				csvWriter.Write(klineCsvRecord(kline))
+			}
+		}
+		// Flush each page so an interrupted download can be resumed.
+		csvWriter.Flush()
+		if err := csvWriter.Error(); err != nil {
+			return err
+		}
+		count += len(klines)
+		last := klines[len(klines)-1]
+		log.Printf("downloaded %d klines to %s", count,
+			last.OpenTime().UTC().Format(time.RFC3339))
+		return nil
+	})
+	if err != nil {
+		if ctx.Err() != nil {
+			log.Fatalf("interrupted after %d klines", count)
+		}
+		log.Fatal("error: ", err)
+	}
+}
+
+func klineCsvRecord(kline binance.Kline) []string {
+	return []string{
+		strconv.FormatInt(kline.OpenTimeMillis, 10),
+		kline.Open.String(),
+		kline.High.String(),
+		kline.Low.String(),
+		kline.Close.String(),
+		kline.Volume.String(),
+		strconv.FormatInt(kline.CloseTimeMillis, 10),
+		kline.QuoteVolume.String(),
+		strconv.FormatInt(kline.TradeCount, 10),
+		kline.TakerBuyBaseVolume.String(),
+		kline.TakerBuyQuoteVolume.String(),
+	}
+}
+
+// openKlinesFile opens a file for appending klines. If the file already
+// holds klines the time to resume from, just after the close of the last
+// kline, is returned. A partially written last line is removed. Empty is
+// true if nothing has been written to the file, not even a header.
+func openKlinesFile(filename string, format string) (file *os.File, resumeFrom time.Time, empty bool, err error) {
+	file, err = os.OpenFile(filename, os.O_RDWR|os.O_CREATE, 0644)
+	if err != nil {
+		return nil, resumeFrom, false, err
+	}
+	defer func() {
+		if err != nil {
+			file.Close()
+		}
+	}()
+
+	info, err := file.Stat()
+	if err != nil {
+		return nil, resumeFrom, false, err
+	}
+	size := info.Size()
+
+	// Klines are short, the last complete line will be in the last 64k.
+	offset := size - 64*1024
+	if offset < 0 {
+		offset = 0
+	}
+	tail := make([]byte, size-offset)
+	if _, err := file.ReadAt(tail, offset); err != nil && err != io.EOF {
+		return nil, resumeFrom, false, err
+	}
+	if end := bytes.LastIndexByte(tail, '\n') + 1; end < len(tail) {
+		if err := file.Truncate(offset + int64(end)); err != nil {
+			return nil, resumeFrom, false, err
+		}
+		tail = tail[:end]
+	}
+	if _, err := file.Seek(0, io.SeekEnd); err != nil {
+		return nil, resumeFrom, false, err
+	}
+
+	lines := bytes.Split(bytes.TrimRight(tail, "\n"), []byte("\n"))
+	last := lines[len(lines)-1]
+	if len(last) == 0 {
+		return file, resumeFrom, offset+int64(len(tail)) == 0, nil
+	}
+
+	var closeTimeMillis int64
+	if format == "json" {
+		var kline binance.Kline
+		if err := json.Unmarshal(last, &kline); err != nil {
+			return nil, resumeFrom, false, err
+		}
+		closeTimeMillis = kline.CloseTimeMillis
+	} else {
+		record, err := csv.NewReader(bytes.NewReader(last)).Read()
+		if err != nil {
+			return nil, resumeFrom, false, err
+		}
+		if record[0] == klinesCsvHeader[0] {
+			return file, resumeFrom, false, nil
+		}
+		if len(record) != len(klinesCsvHeader) {
+			return nil, resumeFrom, false, fmt.Errorf("unexpected last line in %s", filename)
+		}
+		closeTimeMillis, err = strconv.ParseInt(record[6], 10, 64)
+		if err != nil {
+			return nil, resumeFrom, false, err
+		}
+	}
+	return file, util.MillisToTime(closeTimeMillis + 1), false, nil
+}
@@ -33,7 +33,7 @@ import (
 )
 
 func LastCommand(args []string) {
-	client := binance.NewAnonymousClient()
+	client := binance.NewAnonymousClient(ClientOptions()...)
 	response, err := client.GetAllPriceTicker()
 	if err != nil {
 		log.Fatal("error: ", err)
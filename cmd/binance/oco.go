@@ -0,0 +1,158 @@
+// The MIT License (MIT)
+//
+// Copyright (c) 2018 Cranky Kernel
+//
+// Permission is hereby granted, free of charge, to any person
+// obtaining a copy of this software and associated documentation
+// files (the "Software"), to deal in the Software without
+// restriction, including without limitation the rights to use, copy,
+// modify, merge, publish, distribute, sublicense, and/or sell copies
+// of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be
+// included in all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND,
+// EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF
+// MERCHANTABILITY, FITNESS FOR A PARTICULAR PURPOSE AND
+// NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT HOLDERS
+// BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER IN AN
+// ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN
+// CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package binance
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"strconv"
+	"strings"
+
+	"github.com/spf13/pflag"
+	"github.com/spf13/viper"
+	"gitlab.com/crankykernel/cryptotrader/binance"
+)
+
+func newAuthenticatedClient() *binance.RestClient {
+	return binance.NewAuthenticatedClient(
+		viper.GetString("binance.api.key"),
+		viper.GetString("binance.api.secret"),
+		ClientOptions()...)
+}
+
+func printJSON(v interface{}) {
+	buf, err := json.Marshal(v)
+	if err != nil {
+		log.Fatal("error: ", err)
+	}
+	fmt.Println(string(buf))
+}
+
+// OcoPlaceCommand places an OCO order. The arguments are the side and the
+// symbol.
+func OcoPlaceCommand(flags *pflag.FlagSet, args []string) {
+	if len(args) != 2 {
+		log.Fatal("error: expected arguments: <buy|sell> <symbol>")
+	}
+	side := binance.OrderSide(strings.ToUpper(args[0]))
+	if side != binance.OrderSideBuy && side != binance.OrderSideSell {
+		log.Fatal("error: invalid side: ", args[0])
+	}
+
+	order := binance.OcoOrderParameters{
+		Symbol:          strings.ToUpper(args[1]),
+		Side:            side,
+		Quantity:        decimalFlag(flags, "quantity"),
+		Price:           decimalFlag(flags, "price"),
+		LimitIcebergQty: decimalFlag(flags, "limit-iceberg-quantity"),
+		StopPrice:       decimalFlag(flags, "stop-price"),
+		StopLimitPrice:  decimalFlag(flags, "stop-limit-price"),
+		StopIcebergQty:  decimalFlag(flags, "stop-iceberg-quantity"),
+	}
+	order.ListClientOrderId, _ = flags.GetString("list-client-order-id")
+	order.LimitClientOrderId, _ = flags.GetString("limit-client-order-id")
+	order.StopClientOrderId, _ = flags.GetString("stop-client-order-id")
+	if timeInForce, _ := flags.GetString("stop-limit-time-in-force"); timeInForce != "" {
+		order.StopLimitTimeInForce = binance.TimeInForce(strings.ToUpper(timeInForce))
+	}
+	if responseType, _ := flags.GetString("response-type"); responseType != "" {
+		order.NewOrderRespType = binance.OrderResponseType(strings.ToUpper(responseType))
+	}
+
+	if dryRun, _ := flags.GetBool("dry-run"); dryRun {
+		result, err := newAuthenticatedClient().TestOcoOrder(order)
+		if err != nil {
+			log.Fatal("error: ", err)
+		}
+		printDryRun(result)
+		return
+	}
+
+	response, err := newAuthenticatedClient().PostOcoOrder(order)
+	if err != nil {
+		log.Fatal("error: ", err)
+	}
+	printJSON(response)
+}
+
+// OcoGetCommand prints an order list given its order list ID, or its list
+// client order ID with --client-id.
+func OcoGetCommand(flags *pflag.FlagSet, args []string) {
+	if len(args) != 1 {
+		log.Fatal("error: expected arguments: <order-list-id>")
+	}
+	orderListId, listClientOrderId := orderListIdArg(flags, args[0])
+	response, err := newAuthenticatedClient().GetOrderList(orderListId, listClientOrderId)
+	if err != nil {
+		log.Fatal("error: ", err)
+	}
+	printJSON(response)
+}
+
+// OcoCancelCommand cancels an order list.
+func OcoCancelCommand(flags *pflag.FlagSet, args []string) {
+	if len(args) != 2 {
+		log.Fatal("error: expected arguments: <symbol> <order-list-id>")
+	}
+	orderListId, listClientOrderId := orderListIdArg(flags, args[1])
+	response, err := newAuthenticatedClient().CancelOrderList(
+		strings.ToUpper(args[0]), orderListId, listClientOrderId)
+	if err != nil {
+		log.Fatal("error: ", err)
+	}
+	printJSON(response)
+}
+
+// OcoListCommand prints the open order lists, or with --all the order list
+// history, one per line.
+func OcoListCommand(flags *pflag.FlagSet) {
+	client := newAuthenticatedClient()
+	var response []binance.OrderListResponse
+	var err error
+	if all, _ := flags.GetBool("all"); all {
+		limit, _ := flags.GetInt64("limit")
+		response, err = client.GetAllOrderLists(-1, limit)
+	} else {
+		response, err = client.GetOpenOrderLists()
+	}
+	if err != nil {
+		log.Fatal("error: ", err)
+	}
+	for _, orderList := range response {
+		printJSON(orderList)
+	}
+}
+
+func orderListIdArg(flags *pflag.FlagSet, arg string) (int64, string) {
+	if clientId, _ := flags.GetBool("client-id"); clientId {
+		return 0, arg
+	}
+	orderListId, err := strconv.ParseInt(arg, 10, 64)
+	if err != nil {
+		log.Fatal("error: invalid order list id: ", arg)
+	}
+	return orderListId, ""
+}
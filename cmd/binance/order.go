@@ -0,0 +1,107 @@
+// The MIT License (MIT)
+//
+// Copyright (c) 2018 Cranky Kernel
+//
+// Permission is hereby granted, free of charge, to any person
+// obtaining a copy of this software and associated documentation
+// files (the "Software"), to deal in the Software without
+// restriction, including without limitation the rights to use, copy,
+// modify, merge, publish, distribute, sublicense, and/or sell copies
+// of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be
+// included in all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND,
+// EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF
+// MERCHANTABILITY, FITNESS FOR A PARTICULAR PURPOSE AND
+// NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT HOLDERS
+// BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER IN AN
+// ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN
+// CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package binance
+
+import (
+	"fmt"
+	"log"
+	"os"
+	"strings"
+
+	"github.com/spf13/pflag"
+	"gitlab.com/crankykernel/cryptotrader/binance"
+	"gitlab.com/crankykernel/cryptotrader/decimal"
+)
+
+// OrderCommand places an order of the given type. The arguments are the
+// side and the symbol, everything else is taken from the flags.
+func OrderCommand(orderType binance.OrderType, flags *pflag.FlagSet, args []string) {
+	if len(args) != 2 {
+		log.Fatal("error: expected arguments: <buy|sell> <symbol>")
+	}
+
+	side := binance.OrderSide(strings.ToUpper(args[0]))
+	if side != binance.OrderSideBuy && side != binance.OrderSideSell {
+		log.Fatal("error: invalid side: ", args[0])
+	}
+
+	order := binance.OrderParameters{
+		Symbol:        strings.ToUpper(args[1]),
+		Side:          side,
+		Type:          orderType,
+		Quantity:      decimalFlag(flags, "quantity"),
+		QuoteOrderQty: decimalFlag(flags, "quote-quantity"),
+		Price:         decimalFlag(flags, "price"),
+		StopPrice:     decimalFlag(flags, "stop-price"),
+		IcebergQty:    decimalFlag(flags, "iceberg-quantity"),
+	}
+	if timeInForce, _ := flags.GetString("time-in-force"); timeInForce != "" {
+		order.TimeInForce = binance.TimeInForce(strings.ToUpper(timeInForce))
+	}
+	if clientOrderId, _ := flags.GetString("client-order-id"); clientOrderId != "" {
+		order.NewClientOrderId = clientOrderId
+	}
+	if responseType, _ := flags.GetString("response-type"); responseType != "" {
+		order.NewOrderRespType = binance.OrderResponseType(strings.ToUpper(responseType))
+	}
+
+	if dryRun, _ := flags.GetBool("dry-run"); dryRun {
+		result, err := newAuthenticatedClient().TestOrder(order)
+		if err != nil {
+			log.Fatal("error: ", err)
+		}
+		printDryRun(result)
+		return
+	}
+
+	response, err := newAuthenticatedClient().PostOrder(order)
+	if err != nil {
+		log.Fatal("error: ", err)
+	}
+	printJSON(response)
+}
+
+// decimalFlag returns the value of a decimal flag, or zero if the flag is
+// not set or not defined for the command.
+func decimalFlag(flags *pflag.FlagSet, name string) decimal.Decimal {
+	value, _ := flags.GetString(name)
+	if value == "" {
+		return decimal.Zero
+	}
+	d, err := decimal.Parse(value)
+	if err != nil {
+		log.Fatalf("error: invalid --%s: %s", name, value)
+	}
+	return d
+}
+
+// printDryRun prints a dry run report, exiting with an error status if the
+// order would fail.
+func printDryRun(dryRun *binance.OrderDryRun) {
+	fmt.Println(dryRun.String())
+	if !dryRun.Ok() {
+		os.Exit(1)
+	}
+}
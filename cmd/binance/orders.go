@@ -0,0 +1,151 @@
+// The MIT License (MIT)
+//
+// Copyright (c) 2018 Cranky Kernel
+//
+// Permission is hereby granted, free of charge, to any person
+// obtaining a copy of this software and associated documentation
+// files (the "Software"), to deal in the Software without
+// restriction, including without limitation the rights to use, copy,
+// modify, merge, publish, distribute, sublicense, and/or sell copies
+// of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be
+// included in all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND,
+// EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF
+// MERCHANTABILITY, FITNESS FOR A PARTICULAR PURPOSE AND
+// NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT HOLDERS
+// BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER IN AN
+// ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN
+// CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package binance
+
+import (
+	"log"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/spf13/pflag"
+	"gitlab.com/crankykernel/cryptotrader/binance"
+	"gitlab.com/crankykernel/cryptotrader/util"
+)
+
+// OrdersCommand prints the open orders for a symbol or all symbols, or
+// with --all the order history of a symbol.
+func OrdersCommand(flags *pflag.FlagSet, args []string) {
+	symbol := ""
+	if len(args) > 0 {
+		symbol = strings.ToUpper(args[0])
+	}
+	format, _ := flags.GetString("format")
+
+	client := newAuthenticatedClient()
+	var orders []binance.QueryOrderResponse
+	var err error
+	if all, _ := flags.GetBool("all"); all {
+		if symbol == "" {
+			log.Fatal("error: a symbol is required with --all")
+		}
+		orders, err = client.GetAllOrdersBetween(symbol,
+			timeFlag(flags, "start"), timeFlag(flags, "end"))
+	} else {
+		orders, err = client.GetOpenOrders(symbol)
+	}
+	if err != nil {
+		log.Fatal("error: ", err)
+	}
+
+	header := []string{"time", "symbol", "order_id", "client_order_id", "side",
+		"type", "price", "stop_price", "quantity", "executed", "status"}
+	rows := [][]string{}
+	items := []interface{}{}
+	for _, order := range orders {
+		rows = append(rows, []string{
+			util.MillisToTime(order.TimeMillis).UTC().Format("2006-01-02 15:04:05"),
+			order.Symbol,
+			strconv.FormatInt(order.OrderId, 10),
+			order.ClientOrderId,
+			string(order.Side),
+			string(order.Type),
+			order.Price.String(),
+			order.StopPrice.String(),
+			order.OrigQty.String(),
+			order.ExecutedQty.String(),
+			string(order.Status),
+		})
+		items = append(items, order)
+	}
+	printRows(format, header, rows, items)
+}
+
+// CancelCommand cancels orders on a symbol by order ID, or with --all every
+// open order on the symbol.
+func CancelCommand(flags *pflag.FlagSet, args []string) {
+	all, _ := flags.GetBool("all")
+	if len(args) < 1 || (!all && len(args) < 2) {
+		log.Fatal("error: expected arguments: <symbol> <order-id>... or --all <symbol>")
+	}
+	symbol := strings.ToUpper(args[0])
+	format, _ := flags.GetString("format")
+
+	client := newAuthenticatedClient()
+	cancelled := []binance.CancelOrderResponse{}
+	if all {
+		response, err := client.CancelAllOrders(symbol)
+		if err != nil {
+			log.Fatal("error: ", err)
+		}
+		cancelled = response
+	} else {
+		for _, arg := range args[1:] {
+			orderId, err := strconv.ParseInt(arg, 10, 64)
+			if err != nil {
+				log.Fatal("error: invalid order id: ", arg)
+			}
+			response, err := client.CancelOrder(symbol, orderId)
+			if err != nil {
+				log.Fatalf("error: failed to cancel order %d: %v", orderId, err)
+			}
+			cancelled = append(cancelled, *response)
+		}
+	}
+
+	header := []string{"symbol", "order_id", "client_order_id", "side", "type",
+		"price", "quantity", "executed", "status"}
+	rows := [][]string{}
+	items := []interface{}{}
+	for _, order := range cancelled {
+		rows = append(rows, []string{
+			order.Symbol,
+			strconv.FormatInt(order.OrderID, 10),
+			order.OrigClientOrderID,
+			string(order.Side),
+			string(order.Type),
+			order.Price.String(),
+			order.OrigQty.String(),
+			order.ExecutedQty.String(),
+			string(order.Status),
+		})
+		items = append(items, order)
+	}
+	printRows(format, header, rows, items)
+}
+
+// timeFlag returns the value of a time flag, or the zero time if the flag
+// is not set.
+func timeFlag(flags *pflag.FlagSet, name string) time.Time {
+	value, _ := flags.GetString(name)
+	if value == "" {
+		return time.Time{}
+	}
+	t, err := util.ParseTime(value)
+	if err != nil {
+		log.Fatalf("error: invalid --%s: %s", name, value)
+	}
+	return t
+}
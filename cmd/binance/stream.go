@@ -0,0 +1,81 @@
+// The MIT License (MIT)
+//
+// Copyright (c) 2018 Cranky Kernel
+//
+// Permission is hereby granted, free of charge, to any person
+// obtaining a copy of this software and associated documentation
+// files (the "Software"), to deal in the Software without
+// restriction, including without limitation the rights to use, copy,
+// modify, merge, publish, distribute, sublicense, and/or sell copies
+// of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be
+// included in all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND,
+// EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF
+// MERCHANTABILITY, FITNESS FOR A PARTICULAR PURPOSE AND
+// NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT HOLDERS
+// BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER IN AN
+// ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN
+// CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package binance
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+
+	"github.com/spf13/pflag"
+	"gitlab.com/crankykernel/cryptotrader/binance"
+)
+
+// StreamCommand prints the messages of one or more streams until
+// interrupted, reconnecting if the connection is lost.
+func StreamCommand(flags *pflag.FlagSet, args []string) {
+	if len(args) == 0 {
+		log.Fatal("error: expected arguments: <stream>...")
+	}
+	stream := binance.NewManagedStream(args, ClientOptions()...)
+	stream.Single, _ = flags.GetBool("single")
+
+	ctx := interruptContext()
+	channel := make(chan binance.ManagedStreamEvent)
+	result := make(chan error, 1)
+	go func() {
+		result <- stream.Run(ctx, channel)
+	}()
+
+	for {
+		select {
+		case event := <-channel:
+			switch event.Type {
+			case binance.ManagedStreamConnected:
+				log.Println("Connected!")
+			case binance.ManagedStreamReconnected:
+				log.Println("Reconnected, messages may have been missed.")
+			case binance.ManagedStreamDisconnected:
+				log.Printf("Disconnected: %v; retrying in %v", event.Err, event.RetryIn)
+			case binance.ManagedStreamMessage:
+				var message interface{}
+				if err := json.Unmarshal(event.Message, &message); err != nil {
+					log.Printf("warning: %v: %s", err, event.Message)
+					continue
+				}
+				txt, err := json.Marshal(message)
+				if err != nil {
+					log.Fatal("error: ", err)
+				}
+				fmt.Printf("%s\n", txt)
+			}
+		case err := <-result:
+			if ctx.Err() == nil {
+				log.Fatal("error: ", err)
+			}
+			return
+		}
+	}
+}
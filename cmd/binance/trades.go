@@ -0,0 +1,101 @@
+// The MIT License (MIT)
+//
+// Copyright (c) 2018 Cranky Kernel
+//
+// Permission is hereby granted, free of charge, to any person
+// obtaining a copy of this software and associated documentation
+// files (the "Software"), to deal in the Software without
+// restriction, including without limitation the rights to use, copy,
+// modify, merge, publish, distribute, sublicense, and/or sell copies
+// of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be
+// included in all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND,
+// EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF
+// MERCHANTABILITY, FITNESS FOR A PARTICULAR PURPOSE AND
+// NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT HOLDERS
+// BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER IN AN
+// ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN
+// CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package binance
+
+import (
+	"log"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/spf13/pflag"
+	"gitlab.com/crankykernel/cryptotrader/binance"
+	"gitlab.com/crankykernel/cryptotrader/util"
+)
+
+// TradesCommand prints the trade history of the symbols given as arguments
+// or with --all every symbol the account may have traded.
+func TradesCommand(flags *pflag.FlagSet, args []string) {
+	format, _ := flags.GetString("format")
+	client := newAuthenticatedClient()
+
+	symbols := []string{}
+	for _, arg := range args {
+		symbols = append(symbols, strings.ToUpper(arg))
+	}
+	if all, _ := flags.GetBool("all"); all {
+		if len(symbols) > 0 {
+			log.Fatal("error: symbols cannot be given with --all")
+		}
+		var err error
+		symbols, err = client.GetAccountSymbols()
+		if err != nil {
+			log.Fatal("error: ", err)
+		}
+		log.Printf("checking %d symbols for trades", len(symbols))
+	}
+	if len(symbols) == 0 {
+		log.Fatal("error: expected arguments: <symbol>... or --all")
+	}
+
+	trades := []binance.TradeResponse{}
+	iterator := client.IterateMyTrades(symbols,
+		timeFlag(flags, "start"), timeFlag(flags, "end"))
+	for iterator.Next() {
+		trades = append(trades, iterator.Trade())
+	}
+	if err := iterator.Err(); err != nil {
+		log.Fatalf("error: failed to get trades for %s: %v", iterator.Symbol(), err)
+	}
+	sort.SliceStable(trades, func(i, j int) bool {
+		return trades[i].TimeMillis < trades[j].TimeMillis
+	})
+
+	header := []string{"time", "symbol", "trade_id", "order_id", "side", "price",
+		"quantity", "quote_quantity", "commission", "commission_asset", "maker"}
+	rows := [][]string{}
+	items := []interface{}{}
+	for _, trade := range trades {
+		side := binance.OrderSideSell
+		if trade.IsBuyer {
+			side = binance.OrderSideBuy
+		}
+		rows = append(rows, []string{
+			util.MillisToTime(trade.TimeMillis).UTC().Format("2006-01-02 15:04:05"),
+			trade.Symbol,
+			strconv.FormatInt(trade.ID, 10),
+			strconv.FormatInt(trade.OrderID, 10),
+			string(side),
+			trade.Price.String(),
+			trade.Quantity.String(),
+			trade.QuoteQuantity.String(),
+			trade.Commission.String(),
+			trade.CommissionAsset,
+			strconv.FormatBool(trade.IsMaker),
+		})
+		items = append(items, trade)
+	}
+	printRows(format, header, rows, items)
+}
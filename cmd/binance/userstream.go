@@ -28,7 +28,6 @@ import (
 	"github.com/spf13/viper"
 	"log"
 	"gitlab.com/crankykernel/cryptotrader/binance"
-	"encoding/json"
 )
 
 func BinanceUserStreamCommand() {
@@ -38,25 +37,28 @@ func BinanceUserStreamCommand() {
 		log.Fatal("error: this command requires an api key")
 	}
 
-	restClient := binance.NewAuthenticatedClient(apiKey, "")
+	restClient := binance.NewAuthenticatedClient(apiKey, "", ClientOptions()...)
 
-	streamClient, err := binance.OpenUserStream(restClient)
+	ctx := interruptContext()
+	streamClient, err := binance.OpenUserStreamContext(ctx, restClient)
 	if err != nil {
-		log.Fatal("error: failed to open user stream: %v", err)
+		log.Fatalf("error: failed to open user stream: %v", err)
 	}
 
 	for {
-		_, body, err := streamClient.Next()
+		_, body, err := streamClient.NextContext(ctx)
 		if err != nil {
-			log.Fatal("error: failed to read next message: %v", err)
+			if ctx.Err() != nil {
+				return
+			}
+			log.Fatalf("error: failed to read next message: %v", err)
 		}
 
-		var rawOrderUpdate binance.StreamExecutionReport
-		err = json.Unmarshal(body, &rawOrderUpdate)
-		if err == nil {
-			x := binance.StreamExecutionReport(rawOrderUpdate)
-			log.Printf("%+v\n", rawOrderUpdate)
-			log.Printf("%+v\n", x)
+		event, err := binance.DecodeUserStreamEvent(body)
+		if err != nil {
+			log.Printf("warning: %v: %s\n", err, string(body))
+			continue
 		}
+		log.Printf("%+v\n", event)
 	}
 }
@@ -0,0 +1,52 @@
+// The MIT License (MIT)
+//
+// Copyright (c) 2018 Cranky Kernel
+//
+// Permission is hereby granted, free of charge, to any person
+// obtaining a copy of this software and associated documentation
+// files (the "Software"), to deal in the Software without
+// restriction, including without limitation the rights to use, copy,
+// modify, merge, publish, distribute, sublicense, and/or sell copies
+// of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be
+// included in all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND,
+// EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF
+// MERCHANTABILITY, FITNESS FOR A PARTICULAR PURPOSE AND
+// NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT HOLDERS
+// BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER IN AN
+// ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN
+// CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package common
+
+import (
+	"log"
+
+	"gitlab.com/crankykernel/cryptotrader/core"
+)
+
+// LoadSymbols returns a symbol registry holding the symbols of an exchange,
+// exiting if they can not be loaded.
+func LoadSymbols(source core.SymbolSource) *core.SymbolRegistry {
+	registry := core.NewSymbolRegistry()
+	if err := registry.Load(source); err != nil {
+		log.Fatalf("error: failed to load %s symbols: %v", source.Name(), err)
+	}
+	return registry
+}
+
+// ResolvePair returns the canonical pair name for a symbol given on the
+// command line as either a canonical pair (ie: BTC/USD) or an exchange
+// native symbol, exiting if the symbol is not known.
+func ResolvePair(registry *core.SymbolRegistry, exchange string, symbol string) string {
+	native, err := registry.Resolve(exchange, symbol)
+	if err != nil {
+		log.Fatal("error: ", err)
+	}
+	return registry.Canonical(exchange, native)
+}
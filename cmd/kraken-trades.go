@@ -49,4 +49,6 @@ func init() {
 
 	krakenTradesCmd.Flags().Bool("reverse", false, "Display in reverse order.")
 	krakenTradesCmd.Flags().String("format", "", "Output format (ie: csv, tab, ...)")
+	krakenTradesCmd.Flags().String("symbol", "",
+		"Only print trades for a pair (ie: BTC/USD or XXBTZUSD)")
 }
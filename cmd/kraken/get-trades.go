@@ -36,6 +36,7 @@ import (
 	"strings"
 	"strconv"
 	"gitlab.com/crankykernel/cryptotrader/util"
+	"gitlab.com/crankykernel/cryptotrader/cmd/common"
 )
 
 type Trade struct {
@@ -57,6 +58,15 @@ func KrakenGetTrades(opts *pflag.FlagSet, args []string) {
 	client := kraken.NewClient(viper.GetString("kraken.api.key"),
 		viper.GetString("kraken.api.secret"))
 
+	// Pairs are printed, and may be given, in the canonical form (ie:
+	// BTC/USD).
+	exchange := kraken.NewExchange(client)
+	registry := common.LoadSymbols(exchange)
+	symbol, _ := opts.GetString("symbol")
+	if symbol != "" {
+		symbol = common.ResolvePair(registry, exchange.Name(), symbol)
+	}
+
 	params := map[string]interface{}{}
 
 	//params["start"] = 1515980360
@@ -96,7 +106,7 @@ func KrakenGetTrades(opts *pflag.FlagSet, args []string) {
 
 		timestamp, err := util.JsonNumberToTime(trade["time"].(json.Number))
 		if err != nil {
-			log.Fatal("error: failed to parse timestamp: %v", trade["time"])
+			log.Fatalf("error: failed to parse timestamp: %v", trade["time"])
 		}
 
 		trade["id"] = key
@@ -106,9 +116,17 @@ func KrakenGetTrades(opts *pflag.FlagSet, args []string) {
 		sfee := fmt.Sprintf("%.4f", ffee)
 		ffee, _ = strconv.ParseFloat(sfee, 64)
 
+		pair := kraken.GetNormalizePairName(trade["pair"].(string))
+		if _, ok := registry.Pair(exchange.Name(), trade["pair"].(string)); ok {
+			pair = registry.Canonical(exchange.Name(), trade["pair"].(string))
+		}
+		if symbol != "" && pair != symbol {
+			continue
+		}
+
 		xtrade := Trade{
 			Timestamp: timestamp,
-			Pair:      kraken.GetNormalizePairName(trade["pair"].(string)),
+			Pair:      pair,
 			Type:      strings.Title(trade["type"].(string)),
 			Cost:      trade["cost"].(string),
 			Fee:       trade["fee"].(string),
@@ -27,11 +27,11 @@ package kraken
 import (
 	"log"
 	"strings"
-	"io/ioutil"
 	"fmt"
 	"gitlab.com/crankykernel/cryptotrader/kraken"
 	"net/http"
 	"github.com/spf13/viper"
+	"gitlab.com/crankykernel/cryptotrader/util"
 )
 
 func KrakenGetCmd(args []string) {
@@ -82,7 +82,7 @@ func get(method string, args []string) {
 		log.Fatal("error: ", err)
 	}
 
-	body, err := ioutil.ReadAll(response.Body)
+	body, err := util.ReadAll(response)
 	if err != nil {
 		log.Fatal("error: ", err)
 	}
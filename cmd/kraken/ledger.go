@@ -91,8 +91,11 @@ func printMergedPretty(merged []MergedEntry) {
 		var fee float64
 		var feeAsset string
 
+		// Print the common asset names (ie: BTC rather than XXBT).
 		out := entry.Out
+		out.Asset = kraken.NormalizeAssetName(out.Asset)
 		in_ := entry.In
+		in_.Asset = kraken.NormalizeAssetName(in_.Asset)
 
 		if out.Fee > 0 {
 			fee = out.Fee
@@ -165,7 +168,7 @@ func printMergedDelim(entries []MergedEntry, delim string) {
 				e.Out.Timestamp.Format("2006-01-02 15:04:05"),
 				"withdrawal",
 				"",
-				e.Out.Asset,
+				kraken.NormalizeAssetName(e.Out.Asset),
 				"",
 				"",
 				fmt.Sprintf("%.8f", e.Out.Amount),
@@ -60,4 +60,6 @@ func init() {
 	flags.UintVar(&kucoin.GetTradesFlags.Limit, "limit", 20,
 		"Limit the result to a number of trades")
 	flags.BoolVar(&kucoin.GetTradesFlags.All, "all", false, "Print all trades")
+	flags.StringVar(&kucoin.GetTradesFlags.Symbol, "symbol", "",
+		"Only print trades for a pair (ie: ETH/BTC or ETH-BTC)")
 }
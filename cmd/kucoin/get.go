@@ -28,8 +28,8 @@ import (
 	"github.com/sirupsen/logrus"
 	"log"
 	"strings"
-	"io/ioutil"
 	"fmt"
+	"gitlab.com/crankykernel/cryptotrader/util"
 )
 
 func Get(args []string) {
@@ -54,7 +54,7 @@ func Get(args []string) {
 	if err != nil {
 		logrus.Fatal(err)
 	}
-	body, err := ioutil.ReadAll(response.Body)
+	body, err := util.ReadAll(response)
 	fmt.Println(string(body))
 }
 
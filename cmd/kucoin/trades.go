@@ -30,23 +30,36 @@ import (
 	"gitlab.com/crankykernel/cryptotrader/kucoin"
 	"encoding/json"
 	"strings"
+	"gitlab.com/crankykernel/cryptotrader/cmd/common"
+	"gitlab.com/crankykernel/cryptotrader/core"
 )
 
 var GetTradesFlags struct {
 	Format string
 	Limit  uint
 	All    bool
+	Symbol string
 }
 
 func GetTrades() {
 	page := 0
 	count := 0
+	seen := 0
 
 	if GetTradesFlags.All {
 		// For limit to 0.
 		GetTradesFlags.Limit = 0
 	}
 
+	// Pairs are printed, and may be given, in the canonical form (ie:
+	// ETH/BTC).
+	exchange := kucoin.NewExchange(getClient())
+	registry := common.LoadSymbols(exchange)
+	symbol := GetTradesFlags.Symbol
+	if symbol != "" {
+		symbol = common.ResolvePair(registry, exchange.Name(), symbol)
+	}
+
 Loop:
 	for {
 		// Grab in batches of 100, even though the limit may be less.
@@ -54,22 +67,29 @@ Loop:
 		if err != nil {
 			log.Fatal(err)
 		}
-		if !response.Success {
-			log.Fatal("error: ", response.Raw)
-		}
 
 		for _, trade := range response.Data.Trades {
+			seen += 1
+			pair := core.NewPair(trade.CoinType, trade.CoinTypePair).String()
+			native := fmt.Sprintf("%s-%s", trade.CoinType, trade.CoinTypePair)
+			if _, ok := registry.Pair(exchange.Name(), native); ok {
+				pair = registry.Canonical(exchange.Name(), native)
+			}
+			if symbol != "" && pair != symbol {
+				continue
+			}
+
 			switch GetTradesFlags.Format {
 			case "raw":
 				renderRaw(trade)
 			case "csv":
-				renderDelim(count, trade, ",")
+				renderDelim(count, trade, pair, ",")
 			case "tab":
-				renderDelim(count, trade, "\t")
+				renderDelim(count, trade, pair, "\t")
 			case "default":
 				fallthrough
 			default:
-				renderDefault(trade)
+				renderDefault(trade, pair)
 			}
 
 			count += 1
@@ -79,7 +99,7 @@ Loop:
 			}
 		}
 
-		if int64(count) == response.Data.Total {
+		if int64(seen) >= response.Data.Total || len(response.Data.Trades) == 0 {
 			break
 		}
 
@@ -87,7 +107,7 @@ Loop:
 	}
 }
 
-func renderDelim(i int, trade *kucoin.Trade, delim string) {
+func renderDelim(i int, trade *kucoin.Trade, pair string, delim string) {
 	if i == 0 {
 		header := []string{
 			"timestamp",
@@ -102,7 +122,7 @@ func renderDelim(i int, trade *kucoin.Trade, delim string) {
 	parts := []string{
 		trade.Timestamp.Format("2006-01-02 15:04:05"),
 		trade.Direction,
-		fmt.Sprintf("%s/%s", trade.CoinType, trade.CoinTypePair),
+		pair,
 		fmt.Sprintf("%.8f", trade.DealValue),
 		fmt.Sprintf("%.8f", trade.Fee),
 		fmt.Sprintf("%.8f", trade.Amount),
@@ -110,7 +130,7 @@ func renderDelim(i int, trade *kucoin.Trade, delim string) {
 	fmt.Printf("%s\n", strings.Join(parts, delim))
 }
 
-func renderDefault(trade *kucoin.Trade) {
+func renderDefault(trade *kucoin.Trade, pair string) {
 	var feeCoin string
 	if trade.Direction == "BUY" {
 		feeCoin = trade.CoinType
@@ -121,15 +141,14 @@ func renderDefault(trade *kucoin.Trade) {
 	fmt.Printf(
 		"Timestamp: %s; "+
 			"Action: %-4s; "+
-			"Pair: %s/%s; "+
+			"Pair: %s; "+
 			"Amount: %.8f %s; "+
 			"Cost: %.8f %s; "+
 			"Fee: %.8f %s; "+
 			"\n",
 		trade.Timestamp.Format("2006-01-02 15:04:05"),
 		strings.Title(strings.ToLower(trade.Direction)),
-		trade.CoinType,
-		trade.CoinTypePair,
+		pair,
 		trade.Amount, trade.CoinType,
 		trade.DealValue, trade.CoinTypePair,
 		trade.Fee, feeCoin)
@@ -138,7 +157,7 @@ func renderDefault(trade *kucoin.Trade) {
 func renderRaw(trade *kucoin.Trade) {
 	buf, err := json.Marshal(trade)
 	if err != nil {
-		log.Fatal("error: failed to render trade: %v", err)
+		log.Fatalf("error: failed to render trade: %v", err)
 	}
 	fmt.Printf("%s\n", buf)
 }
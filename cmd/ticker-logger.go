@@ -36,9 +36,12 @@ var tickerLoggerCmd = &cobra.Command{
 By default the tickers will be updated every minute on the minute. This can be
 disabled by setting a non-0 interval value.
 
+Symbols may be given as canonical pairs (BTC/USD) or in the exchange's native
+form (XXBTZUSD, BTC-USD). Tickers are always logged with the canonical pair.
+
 Example:
 
-  cryptotrader ticker-logging kraken:xbtusd,xbtcad binance:bnbusdt binance:btcusdt
+  cryptotrader ticker-logger kraken:xbtusd,btc/cad binance:bnbusdt binance:btc/usdt gdax:btc-usd
 `,
 	Run: func(cmd *cobra.Command, args []string) {
 		tickerlogger.TickerLoggerCommand(args)
@@ -16,7 +16,10 @@ package tickerlogger
 
 import (
 	"gitlab.com/crankykernel/cryptotrader/binance"
+	"gitlab.com/crankykernel/cryptotrader/core"
+	"gitlab.com/crankykernel/cryptotrader/gdax"
 	"gitlab.com/crankykernel/cryptotrader/kraken"
+	"gitlab.com/crankykernel/cryptotrader/kucoin"
 	"strings"
 	"log"
 	"time"
@@ -30,16 +33,6 @@ var Flags struct {
 	OnTheMinute bool
 }
 
-var Binance struct {
-	Client  *binance.RestClient
-	Symbols []string
-}
-
-var Kraken struct {
-	Client  *kraken.Client
-	Symbols []string
-}
-
 type NormalizedTicker struct {
 	Timestamp time.Time
 	Exchange  string
@@ -47,7 +40,23 @@ type NormalizedTicker struct {
 	Price     float64
 }
 
+func newExchange(name string) core.Exchange {
+	switch strings.ToUpper(name) {
+	case "BINANCE":
+		return binance.NewExchange(binance.NewAnonymousClient())
+	case "KRAKEN":
+		return kraken.NewExchange(kraken.NewClient("", ""))
+	case "GDAX":
+		return gdax.NewExchange(gdax.NewApiClient())
+	case "KUCOIN":
+		return kucoin.NewExchange(kucoin.NewClient("", ""))
+	}
+	return nil
+}
+
 func TickerLoggerCommand(args []string) {
+	exchanges := map[string]core.Exchange{}
+	symbols := map[string][]string{}
 
 	for _, arg := range args {
 		parts := strings.SplitN(arg, ":", 2)
@@ -55,25 +64,32 @@ func TickerLoggerCommand(args []string) {
 			log.Fatalf("error: invalid symbols %s (format: exchange:symbols)",
 				arg)
 		}
-		exchange := parts[0]
-		symbols := parts[1]
-		switch strings.ToUpper(exchange) {
-		case "KRAKEN":
-			if Kraken.Client == nil {
-				Kraken.Client = kraken.NewClient("", "")
-			}
-			for _, symbol := range strings.Split(symbols, ",") {
-				Kraken.Symbols = append(Kraken.Symbols, strings.ToUpper(symbol))
+		name := strings.ToUpper(parts[0])
+		if exchanges[name] == nil {
+			exchange := newExchange(name)
+			if exchange == nil {
+				log.Fatalf("error: exchange not supported: %s", parts[0])
 			}
-		case "BINANCE":
-			if Binance.Client == nil {
-				Binance.Client = binance.NewAnonymousClient()
-			}
-			for _, symbol := range strings.Split(symbols, ",") {
-				Binance.Symbols = append(Binance.Symbols, strings.ToUpper(symbol))
+			exchanges[name] = exchange
+		}
+		for _, symbol := range strings.Split(parts[1], ",") {
+			symbols[name] = append(symbols[name], strings.ToUpper(symbol))
+		}
+	}
+
+	// Symbols may be given as canonical pairs (ie: BTC/USD) or exchange
+	// native symbols; resolve them all to native symbols.
+	registry := core.NewSymbolRegistry()
+	for name, exchange := range exchanges {
+		if err := registry.Load(exchange); err != nil {
+			log.Fatalf("error: failed to load %s symbols: %v", exchange.Name(), err)
+		}
+		for i, symbol := range symbols[name] {
+			native, err := registry.Resolve(name, symbol)
+			if err != nil {
+				log.Fatal("error: ", err)
 			}
-		default:
-			log.Fatalf("error: exchange not supported: %s", exchange)
+			symbols[name][i] = native
 		}
 	}
 
@@ -89,72 +105,33 @@ func TickerLoggerCommand(args []string) {
 		}
 	}()
 
-	// Kraken loop.
-	wg.Add(1)
-	go func() {
-		defer wg.Done()
-		for {
-			sleep()
-			now := time.Now()
-			tickers, err := Kraken.Client.Ticker(Kraken.Symbols...)
-			if err != nil {
-				log.Println("kraken error: ", err)
-			} else {
-				for _, tick := range tickers {
-					normalizedTicker := NormalizedTicker{
-						Timestamp: now,
-						Exchange:  "Kraken",
-						Symbol:    tick.Pair,
-						Price:     tick.Last,
-					}
-					logChannel <- normalizedTicker
+	for name, exchange := range exchanges {
+		wg.Add(1)
+		go func(exchange core.Exchange, symbols []string) {
+			defer wg.Done()
+			for {
+				sleep()
+				now := time.Now()
+				tickers, err := exchange.GetTickers(symbols...)
+				if err != nil {
+					log.Printf("%s error: %v", strings.ToLower(exchange.Name()), err)
+					continue
 				}
-			}
-		}
-	}()
-
-	// Binance loop.
-	wg.Add(1)
-	go func() {
-		defer wg.Done()
-		if len(Binance.Symbols) < 1 {
-			return
-		}
-		for {
-			sleep()
-			now := time.Now()
-			tickers, err := Binance.Client.GetAllPriceTicker()
-			if err != nil {
-				log.Println("binance error: ", err)
-			} else {
 				for _, tick := range tickers {
-					if !symbolMatch(tick.Symbol, Binance.Symbols) {
-						continue
-					}
-					normalizedTicker := NormalizedTicker{
+					logChannel <- NormalizedTicker{
 						Timestamp: now,
-						Exchange:  "Binance",
-						Symbol:    tick.Symbol,
-						Price:     tick.Price,
+						Exchange:  exchange.Name(),
+						Symbol:    registry.Canonical(exchange.Name(), tick.Symbol),
+						Price:     tick.Last,
 					}
-					logChannel <- normalizedTicker
 				}
 			}
-		}
-	}()
+		}(exchange, symbols[name])
+	}
 
 	wg.Wait()
 }
 
-func symbolMatch(symbol string, symbols []string) bool {
-	for _, s := range symbols {
-		if symbol == s {
-			return true
-		}
-	}
-	return false
-}
-
 func sleep() {
 	if Flags.Interval > 0 {
 		time.Sleep(time.Duration(Flags.Interval) * time.Second)
@@ -0,0 +1,161 @@
+// The MIT License (MIT)
+//
+// Copyright (c) 2018 Cranky Kernel
+//
+// Permission is hereby granted, free of charge, to any person
+// obtaining a copy of this software and associated documentation
+// files (the "Software"), to deal in the Software without
+// restriction, including without limitation the rights to use, copy,
+// modify, merge, publish, distribute, sublicense, and/or sell copies
+// of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be
+// included in all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND,
+// EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF
+// MERCHANTABILITY, FITNESS FOR A PARTICULAR PURPOSE AND
+// NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT HOLDERS
+// BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER IN AN
+// ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN
+// CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package core
+
+import (
+	"errors"
+	"time"
+)
+
+// ErrNotSupported is returned by an Exchange for operations the exchange
+// (or its API) does not provide.
+var ErrNotSupported = errors.New("operation not supported by exchange")
+
+// Exchange is the common interface implemented by each exchange so tools can
+// be written once and run against any exchange. Symbols are the exchange
+// native symbols (ie: BTCUSDT on Binance, XXBTZUSD on Kraken).
+type Exchange interface {
+	// Name returns the display name of the exchange.
+	Name() string
+
+	// GetSymbols returns the symbols traded on the exchange.
+	GetSymbols() ([]Symbol, error)
+
+	// GetTickers returns the tickers for the provided symbols, or all
+	// symbols if none are provided.
+	GetTickers(symbols ...string) ([]Ticker, error)
+
+	// GetBalances returns all non-zero balances.
+	GetBalances() ([]Balance, error)
+
+	// GetOpenOrders returns the open orders for a symbol, or for all symbols
+	// if symbol is empty.
+	GetOpenOrders(symbol string) ([]Order, error)
+
+	// PlaceOrder places a new order returning the order as acknowledged by
+	// the exchange.
+	PlaceOrder(request OrderRequest) (*Order, error)
+
+	// CancelOrder cancels an open order.
+	CancelOrder(symbol string, orderId string) error
+
+	// GetTrades returns the trade history for a symbol, or all symbols if
+	// symbol is empty and the exchange supports it.
+	GetTrades(symbol string) ([]Trade, error)
+
+	// GetTransfers returns deposits and withdrawals for an asset, or all
+	// assets if asset is empty.
+	GetTransfers(asset string) ([]Transfer, error)
+}
+
+type OrderSide string
+
+const (
+	OrderSideBuy  OrderSide = "BUY"
+	OrderSideSell OrderSide = "SELL"
+)
+
+type OrderType string
+
+const (
+	OrderTypeLimit  OrderType = "LIMIT"
+	OrderTypeMarket OrderType = "MARKET"
+)
+
+type TransferType string
+
+const (
+	TransferTypeDeposit    TransferType = "DEPOSIT"
+	TransferTypeWithdrawal TransferType = "WITHDRAWAL"
+)
+
+type Ticker struct {
+	Exchange  string
+	Symbol    string
+	Timestamp time.Time
+	Last      float64
+	Bid       float64
+	Ask       float64
+	Volume    float64
+}
+
+type Balance struct {
+	Asset  string
+	Free   float64
+	Locked float64
+}
+
+func (b Balance) Total() float64 {
+	return b.Free + b.Locked
+}
+
+type OrderRequest struct {
+	Symbol        string
+	Side          OrderSide
+	Type          OrderType
+	Quantity      float64
+	Price         float64
+	ClientOrderId string
+}
+
+type Order struct {
+	Exchange       string
+	Symbol         string
+	OrderId        string
+	ClientOrderId  string
+	Side           OrderSide
+	Type           OrderType
+	Status         string
+	Price          float64
+	Quantity       float64
+	FilledQuantity float64
+	Timestamp      time.Time
+}
+
+type Trade struct {
+	Exchange  string
+	Symbol    string
+	TradeId   string
+	OrderId   string
+	Side      OrderSide
+	Price     float64
+	Quantity  float64
+	Fee       float64
+	FeeAsset  string
+	Timestamp time.Time
+}
+
+type Transfer struct {
+	Exchange   string
+	Asset      string
+	TransferId string
+	Type       TransferType
+	Status     string
+	Amount     float64
+	Fee        float64
+	Address    string
+	TxId       string
+	Timestamp  time.Time
+}
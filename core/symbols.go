@@ -0,0 +1,201 @@
+// The MIT License (MIT)
+//
+// Copyright (c) 2018 Cranky Kernel
+//
+// Permission is hereby granted, free of charge, to any person
+// obtaining a copy of this software and associated documentation
+// files (the "Software"), to deal in the Software without
+// restriction, including without limitation the rights to use, copy,
+// modify, merge, publish, distribute, sublicense, and/or sell copies
+// of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be
+// included in all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND,
+// EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF
+// MERCHANTABILITY, FITNESS FOR A PARTICULAR PURPOSE AND
+// NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT HOLDERS
+// BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER IN AN
+// ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN
+// CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package core
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// Asset names used by some exchanges, such as Kraken, that differ from the
+// commonly used name. This is the one alias table shared by all exchanges.
+var assetAliases = map[string]string{
+	"XBT": "BTC",
+	"XDG": "DOGE",
+}
+
+// NormalizeAsset converts an asset name to its canonical upper case form,
+// resolving known aliases (ie: XBT -> BTC).
+func NormalizeAsset(name string) string {
+	name = strings.ToUpper(strings.TrimSpace(name))
+	if alias, ok := assetAliases[name]; ok {
+		return alias
+	}
+	return name
+}
+
+// Pair is a canonical trading pair. Its string form is BASE/QUOTE (ie:
+// BTC/USD).
+type Pair struct {
+	Base  string
+	Quote string
+}
+
+func NewPair(base string, quote string) Pair {
+	return Pair{
+		Base:  NormalizeAsset(base),
+		Quote: NormalizeAsset(quote),
+	}
+}
+
+// ParsePair parses a canonical pair name. Both BASE/QUOTE and BASE-QUOTE
+// are accepted.
+func ParsePair(name string) (Pair, error) {
+	parts := strings.FieldsFunc(name, func(r rune) bool {
+		return r == '/' || r == '-'
+	})
+	if len(parts) != 2 {
+		return Pair{}, fmt.Errorf("invalid pair name: %s", name)
+	}
+	return NewPair(parts[0], parts[1]), nil
+}
+
+func (p Pair) String() string {
+	return fmt.Sprintf("%s/%s", p.Base, p.Quote)
+}
+
+// Symbol describes an exchange native symbol along with its base and quote
+// assets. Exchange specific asset naming (ie: Kraken's XXBT) should already
+// be removed from the asset names, common aliases such as XBT are resolved
+// by the registry.
+type Symbol struct {
+	Name       string
+	BaseAsset  string
+	QuoteAsset string
+
+	// Other native names the exchange may use for the same symbol.
+	Aliases []string
+}
+
+// SymbolSource is implemented by exchanges that can list their symbols.
+type SymbolSource interface {
+	Name() string
+	GetSymbols() ([]Symbol, error)
+}
+
+// SymbolRegistry maps exchange native symbols to canonical pairs and back.
+// Exchange names are case insensitive.
+type SymbolRegistry struct {
+	lock     sync.RWMutex
+	toPair   map[string]map[string]Pair
+	toNative map[string]map[Pair]string
+}
+
+func NewSymbolRegistry() *SymbolRegistry {
+	return &SymbolRegistry{
+		toPair:   map[string]map[string]Pair{},
+		toNative: map[string]map[Pair]string{},
+	}
+}
+
+// Add registers a native symbol for an exchange. The first native symbol
+// added for a pair is the one returned by Native.
+func (r *SymbolRegistry) Add(exchange string, symbol Symbol) Pair {
+	exchange = strings.ToUpper(exchange)
+	pair := NewPair(symbol.BaseAsset, symbol.QuoteAsset)
+
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	if r.toPair[exchange] == nil {
+		r.toPair[exchange] = map[string]Pair{}
+		r.toNative[exchange] = map[Pair]string{}
+	}
+	for _, name := range append([]string{symbol.Name}, symbol.Aliases...) {
+		r.toPair[exchange][strings.ToUpper(name)] = pair
+	}
+	if _, ok := r.toNative[exchange][pair]; !ok {
+		r.toNative[exchange][pair] = symbol.Name
+	}
+	return pair
+}
+
+// Load adds all the symbols listed by an exchange.
+func (r *SymbolRegistry) Load(source SymbolSource) error {
+	symbols, err := source.GetSymbols()
+	if err != nil {
+		return err
+	}
+	for _, symbol := range symbols {
+		r.Add(source.Name(), symbol)
+	}
+	return nil
+}
+
+// Pair returns the canonical pair for an exchange native symbol.
+func (r *SymbolRegistry) Pair(exchange string, native string) (Pair, bool) {
+	r.lock.RLock()
+	defer r.lock.RUnlock()
+	pair, ok := r.toPair[strings.ToUpper(exchange)][strings.ToUpper(native)]
+	return pair, ok
+}
+
+// Native returns the exchange native symbol for a canonical pair.
+func (r *SymbolRegistry) Native(exchange string, pair Pair) (string, bool) {
+	r.lock.RLock()
+	defer r.lock.RUnlock()
+	native, ok := r.toNative[strings.ToUpper(exchange)][pair]
+	return native, ok
+}
+
+// Resolve returns the exchange native symbol for a name that may either be
+// a canonical pair name or already an exchange native symbol.
+func (r *SymbolRegistry) Resolve(exchange string, name string) (string, error) {
+	if pair, err := ParsePair(name); err == nil {
+		if native, ok := r.Native(exchange, pair); ok {
+			return native, nil
+		}
+	}
+	if pair, ok := r.Pair(exchange, name); ok {
+		native, _ := r.Native(exchange, pair)
+		return native, nil
+	}
+	return "", fmt.Errorf("unknown symbol for %s: %s", exchange, name)
+}
+
+// Canonical returns the canonical pair name for an exchange native symbol,
+// or the native symbol unmodified if it is not known.
+func (r *SymbolRegistry) Canonical(exchange string, native string) string {
+	if pair, ok := r.Pair(exchange, native); ok {
+		return pair.String()
+	}
+	return native
+}
+
+// Pairs returns the canonical pairs known for an exchange, sorted by name.
+func (r *SymbolRegistry) Pairs(exchange string) []Pair {
+	r.lock.RLock()
+	defer r.lock.RUnlock()
+	pairs := []Pair{}
+	for pair := range r.toNative[strings.ToUpper(exchange)] {
+		pairs = append(pairs, pair)
+	}
+	sort.Slice(pairs, func(i, j int) bool {
+		return pairs[i].String() < pairs[j].String()
+	})
+	return pairs
+}
@@ -0,0 +1,54 @@
+package core
+
+import "testing"
+
+func TestParsePair(t *testing.T) {
+	for _, name := range []string{"BTC/USD", "btc-usd", "XBT/USD"} {
+		pair, err := ParsePair(name)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if pair.String() != "BTC/USD" {
+			t.Errorf("expected BTC/USD for %s, got %s", name, pair)
+		}
+	}
+	if _, err := ParsePair("BTCUSD"); err == nil {
+		t.Errorf("expected error for BTCUSD")
+	}
+}
+
+func TestSymbolRegistry(t *testing.T) {
+	registry := NewSymbolRegistry()
+	registry.Add("Binance", Symbol{
+		Name:       "BTCUSDT",
+		BaseAsset:  "BTC",
+		QuoteAsset: "USDT",
+	})
+	registry.Add("Kraken", Symbol{
+		Name:       "XXBTZUSD",
+		BaseAsset:  "XBT",
+		QuoteAsset: "USD",
+		Aliases:    []string{"XBTUSD"},
+	})
+
+	if name := registry.Canonical("binance", "BTCUSDT"); name != "BTC/USDT" {
+		t.Errorf("expected BTC/USDT, got %s", name)
+	}
+	if name := registry.Canonical("KRAKEN", "xbtusd"); name != "BTC/USD" {
+		t.Errorf("expected BTC/USD, got %s", name)
+	}
+
+	for _, name := range []string{"BTC/USD", "XBTUSD", "XXBTZUSD"} {
+		native, err := registry.Resolve("Kraken", name)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if native != "XXBTZUSD" {
+			t.Errorf("expected XXBTZUSD for %s, got %s", name, native)
+		}
+	}
+
+	if _, err := registry.Resolve("Binance", "BTC/USD"); err == nil {
+		t.Errorf("expected error resolving BTC/USD on Binance")
+	}
+}
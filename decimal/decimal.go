@@ -0,0 +1,478 @@
+// The MIT License (MIT)
+//
+// Copyright (c) 2018 Cranky Kernel
+//
+// Permission is hereby granted, free of charge, to any person
+// obtaining a copy of this software and associated documentation
+// files (the "Software"), to deal in the Software without
+// restriction, including without limitation the rights to use, copy,
+// modify, merge, publish, distribute, sublicense, and/or sell copies
+// of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be
+// included in all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND,
+// EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF
+// MERCHANTABILITY, FITNESS FOR A PARTICULAR PURPOSE AND
+// NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT HOLDERS
+// BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER IN AN
+// ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN
+// CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+// Package decimal provides an exact fixed-point decimal type for prices and
+// quantities.
+package decimal
+
+import (
+	"bytes"
+	"fmt"
+	"math/big"
+	"strconv"
+	"strings"
+)
+
+var bigZero = big.NewInt(0)
+var bigOne = big.NewInt(1)
+var bigTen = big.NewInt(10)
+
+// Decimal is an immutable decimal number represented as an arbitrary
+// precision integer coefficient and the number of digits after the decimal
+// point (scale). The value is coefficient * 10^-scale. The zero value is 0.
+//
+// The scale of parsed values is preserved so a value such as "0.00100000"
+// formats back to the same string.
+type Decimal struct {
+	coefficient *big.Int
+	scale       int32
+}
+
+var Zero = Decimal{}
+
+// MAX_SCALE limits the number of digits after the decimal point, and the
+// number of trailing zeros an exponent may add, when parsing. Larger values
+// are rejected rather than allocating huge coefficients.
+const MAX_SCALE = 64
+
+// New returns value * 10^-scale, ie: New(123, 2) is 1.23.
+func New(value int64, scale int32) Decimal {
+	if scale < 0 {
+		return Decimal{
+			coefficient: new(big.Int).Mul(big.NewInt(value), pow10(-scale)),
+		}
+	}
+	return Decimal{
+		coefficient: big.NewInt(value),
+		scale:       scale,
+	}
+}
+
+func NewFromInt(value int64) Decimal {
+	return New(value, 0)
+}
+
+// NewFromFloat64 converts a float64 using the shortest representation that
+// converts back to the same float64, so 0.1 becomes exactly 0.1.
+func NewFromFloat64(value float64) Decimal {
+	d, err := Parse(strconv.FormatFloat(value, 'f', -1, 64))
+	if err != nil {
+		// Only NaN and infinities fail to parse.
+		return Zero
+	}
+	return d
+}
+
+// Parse parses a decimal string such as "-123.4500". An exponent (ie:
+// "1e-8") is also accepted. An error is returned if the resulting scale is
+// outside of -MAX_SCALE to MAX_SCALE.
+func Parse(value string) (Decimal, error) {
+	s := strings.TrimSpace(value)
+	exponent := int64(0)
+	if i := strings.IndexAny(s, "eE"); i > -1 {
+		var err error
+		exponent, err = strconv.ParseInt(s[i+1:], 10, 32)
+		if err != nil {
+			return Zero, fmt.Errorf("invalid decimal: %q", value)
+		}
+		s = s[:i]
+	}
+
+	digits := s
+	scale := int64(0)
+	if i := strings.IndexByte(s, '.'); i > -1 {
+		digits = s[:i] + s[i+1:]
+		scale = int64(len(s) - i - 1)
+	}
+	unsigned := strings.TrimLeft(digits, "+-")
+	if unsigned == "" || len(digits)-len(unsigned) > 1 ||
+		strings.Trim(unsigned, "0123456789") != "" {
+		return Zero, fmt.Errorf("invalid decimal: %q", value)
+	}
+
+	coefficient, ok := new(big.Int).SetString(digits, 10)
+	if !ok {
+		return Zero, fmt.Errorf("invalid decimal: %q", value)
+	}
+
+	scale -= exponent
+	if scale > MAX_SCALE || scale < -MAX_SCALE {
+		return Zero, fmt.Errorf("decimal out of range: %q", value)
+	}
+	if scale < 0 {
+		coefficient.Mul(coefficient, pow10(int32(-scale)))
+		scale = 0
+	}
+	return Decimal{
+		coefficient: coefficient,
+		scale:       int32(scale),
+	}, nil
+}
+
+// MustParse is like Parse but panics if the value can not be parsed. It is
+// intended for constants.
+func MustParse(value string) Decimal {
+	d, err := Parse(value)
+	if err != nil {
+		panic(err)
+	}
+	return d
+}
+
+func pow10(n int32) *big.Int {
+	return new(big.Int).Exp(bigTen, big.NewInt(int64(n)), nil)
+}
+
+func (d Decimal) coef() *big.Int {
+	if d.coefficient == nil {
+		return bigZero
+	}
+	return d.coefficient
+}
+
+// Scale returns the number of digits after the decimal point.
+func (d Decimal) Scale() int32 {
+	return d.scale
+}
+
+// rescale returns the coefficient of d at a larger scale.
+func (d Decimal) rescale(scale int32) *big.Int {
+	if scale == d.scale {
+		return d.coef()
+	}
+	return new(big.Int).Mul(d.coef(), pow10(scale-d.scale))
+}
+
+func maxScale(a Decimal, b Decimal) int32 {
+	if a.scale > b.scale {
+		return a.scale
+	}
+	return b.scale
+}
+
+func (d Decimal) Add(o Decimal) Decimal {
+	scale := maxScale(d, o)
+	return Decimal{
+		coefficient: new(big.Int).Add(d.rescale(scale), o.rescale(scale)),
+		scale:       scale,
+	}
+}
+
+func (d Decimal) Sub(o Decimal) Decimal {
+	scale := maxScale(d, o)
+	return Decimal{
+		coefficient: new(big.Int).Sub(d.rescale(scale), o.rescale(scale)),
+		scale:       scale,
+	}
+}
+
+func (d Decimal) Mul(o Decimal) Decimal {
+	return Decimal{
+		coefficient: new(big.Int).Mul(d.coef(), o.coef()),
+		scale:       d.scale + o.scale,
+	}
+}
+
+// Div returns d / o rounded half away from zero to the given number of
+// decimal places. Div panics if o is zero.
+func (d Decimal) Div(o Decimal, places int32) Decimal {
+	if o.IsZero() {
+		panic("decimal: division by zero")
+	}
+	// Compute with one extra digit then round.
+	numerator := new(big.Int).Set(d.coef())
+	denominator := new(big.Int).Set(o.coef())
+	shift := places + 1 + o.scale - d.scale
+	if shift > 0 {
+		numerator.Mul(numerator, pow10(shift))
+	} else if shift < 0 {
+		denominator.Mul(denominator, pow10(-shift))
+	}
+	quotient := new(big.Int).Quo(numerator, denominator)
+	return Decimal{
+		coefficient: quotient,
+		scale:       places + 1,
+	}.Round(places)
+}
+
+func (d Decimal) Neg() Decimal {
+	return Decimal{
+		coefficient: new(big.Int).Neg(d.coef()),
+		scale:       d.scale,
+	}
+}
+
+func (d Decimal) Abs() Decimal {
+	if d.Sign() < 0 {
+		return d.Neg()
+	}
+	return d
+}
+
+// Sign returns -1, 0 or 1.
+func (d Decimal) Sign() int {
+	return d.coef().Sign()
+}
+
+func (d Decimal) IsZero() bool {
+	return d.Sign() == 0
+}
+
+// Cmp returns -1 if d < o, 0 if d == o and 1 if d > o.
+func (d Decimal) Cmp(o Decimal) int {
+	scale := maxScale(d, o)
+	return d.rescale(scale).Cmp(o.rescale(scale))
+}
+
+// Equal compares values, so 1.0 equals 1.00.
+func (d Decimal) Equal(o Decimal) bool {
+	return d.Cmp(o) == 0
+}
+
+func (d Decimal) LessThan(o Decimal) bool {
+	return d.Cmp(o) < 0
+}
+
+func (d Decimal) GreaterThan(o Decimal) bool {
+	return d.Cmp(o) > 0
+}
+
+type roundingMode int
+
+const (
+	roundHalfUp roundingMode = iota
+	roundDown
+	roundFloor
+	roundCeil
+)
+
+// roundQuotient adjusts the truncated quotient of a division by divisor
+// according to the rounding mode and the remainder.
+func roundQuotient(quotient *big.Int, remainder *big.Int, divisor *big.Int, mode roundingMode) {
+	if remainder.Sign() == 0 {
+		return
+	}
+	switch mode {
+	case roundHalfUp:
+		half := new(big.Int).Abs(remainder)
+		half.Mul(half, big.NewInt(2))
+		if half.Cmp(new(big.Int).Abs(divisor)) >= 0 {
+			if remainder.Sign() > 0 {
+				quotient.Add(quotient, bigOne)
+			} else {
+				quotient.Sub(quotient, bigOne)
+			}
+		}
+	case roundFloor:
+		if remainder.Sign() < 0 {
+			quotient.Sub(quotient, bigOne)
+		}
+	case roundCeil:
+		if remainder.Sign() > 0 {
+			quotient.Add(quotient, bigOne)
+		}
+	}
+}
+
+// round rounds to the given number of decimal places. Values that already
+// have fewer places are returned unchanged. Negative places round to the
+// left of the decimal point, ie: 123 rounded to -1 places is 120, and the
+// result has a scale of 0.
+func (d Decimal) round(places int32, mode roundingMode) Decimal {
+	if places >= d.scale {
+		return d
+	}
+	divisor := pow10(d.scale - places)
+	quotient, remainder := new(big.Int).QuoRem(d.coef(), divisor, new(big.Int))
+	roundQuotient(quotient, remainder, divisor, mode)
+	if places < 0 {
+		return Decimal{
+			coefficient: quotient.Mul(quotient, pow10(-places)),
+		}
+	}
+	return Decimal{
+		coefficient: quotient,
+		scale:       places,
+	}
+}
+
+// Round rounds half away from zero to the given number of decimal places.
+func (d Decimal) Round(places int32) Decimal {
+	return d.round(places, roundHalfUp)
+}
+
+// Truncate rounds towards zero to the given number of decimal places.
+func (d Decimal) Truncate(places int32) Decimal {
+	return d.round(places, roundDown)
+}
+
+func (d Decimal) Floor(places int32) Decimal {
+	return d.round(places, roundFloor)
+}
+
+func (d Decimal) Ceil(places int32) Decimal {
+	return d.round(places, roundCeil)
+}
+
+// toStep rounds to a multiple of step. The result has the scale of step. A
+// zero step returns d unchanged.
+func (d Decimal) toStep(step Decimal, mode roundingMode) Decimal {
+	if step.IsZero() {
+		return d
+	}
+	step = step.Abs()
+	scale := maxScale(d, step)
+	divisor := step.rescale(scale)
+	quotient, remainder := new(big.Int).QuoRem(d.rescale(scale), divisor, new(big.Int))
+	roundQuotient(quotient, remainder, divisor, mode)
+	return Decimal{coefficient: quotient}.Mul(step)
+}
+
+// FloorToStep rounds down to a multiple of step, ie: a quantity to the
+// step size of a symbol.
+func (d Decimal) FloorToStep(step Decimal) Decimal {
+	return d.toStep(step, roundFloor)
+}
+
+// CeilToStep rounds up to a multiple of step.
+func (d Decimal) CeilToStep(step Decimal) Decimal {
+	return d.toStep(step, roundCeil)
+}
+
+// RoundToStep rounds half away from zero to a multiple of step, ie: a price
+// to the tick size of a symbol.
+func (d Decimal) RoundToStep(step Decimal) Decimal {
+	return d.toStep(step, roundHalfUp)
+}
+
+// IsMultipleOf returns true if d is an exact multiple of step.
+func (d Decimal) IsMultipleOf(step Decimal) bool {
+	if step.IsZero() {
+		return true
+	}
+	scale := maxScale(d, step)
+	remainder := new(big.Int).Rem(d.rescale(scale), step.rescale(scale))
+	return remainder.Sign() == 0
+}
+
+// Normalize removes trailing zeros after the decimal point.
+func (d Decimal) Normalize() Decimal {
+	coefficient := new(big.Int).Set(d.coef())
+	scale := d.scale
+	remainder := new(big.Int)
+	for scale > 0 {
+		quotient, r := new(big.Int).QuoRem(coefficient, bigTen, remainder)
+		if r.Sign() != 0 {
+			break
+		}
+		coefficient = quotient
+		scale--
+	}
+	return Decimal{
+		coefficient: coefficient,
+		scale:       scale,
+	}
+}
+
+func (d Decimal) Float64() float64 {
+	f, _ := strconv.ParseFloat(d.String(), 64)
+	return f
+}
+
+// String formats the value with its scale, ie: "0.00100000".
+func (d Decimal) String() string {
+	digits := new(big.Int).Abs(d.coef()).String()
+	sign := ""
+	if d.Sign() < 0 {
+		sign = "-"
+	}
+	if d.scale <= 0 {
+		return sign + digits
+	}
+	if pad := int(d.scale) + 1 - len(digits); pad > 0 {
+		digits = strings.Repeat("0", pad) + digits
+	}
+	point := len(digits) - int(d.scale)
+	return sign + digits[:point] + "." + digits[point:]
+}
+
+// StringFixed formats the value rounded to the given number of decimal
+// places.
+func (d Decimal) StringFixed(places int32) string {
+	rounded := d.Round(places)
+	if rounded.scale < places {
+		rounded = Decimal{
+			coefficient: rounded.rescale(places),
+			scale:       places,
+		}
+	}
+	return rounded.String()
+}
+
+// MarshalJSON encodes the value as a JSON string to avoid any loss of
+// precision in JSON decoders that use floating point.
+func (d Decimal) MarshalJSON() ([]byte, error) {
+	return []byte(strconv.Quote(d.String())), nil
+}
+
+// UnmarshalJSON accepts a JSON string or number. Null and the empty string
+// decode to zero.
+func (d *Decimal) UnmarshalJSON(data []byte) error {
+	data = bytes.TrimSpace(data)
+	if bytes.Equal(data, []byte("null")) {
+		*d = Zero
+		return nil
+	}
+	value := string(data)
+	if len(data) > 0 && data[0] == '"' {
+		var err error
+		value, err = strconv.Unquote(value)
+		if err != nil {
+			return fmt.Errorf("invalid decimal: %s", data)
+		}
+		if value == "" {
+			*d = Zero
+			return nil
+		}
+	}
+	parsed, err := Parse(value)
+	if err != nil {
+		return err
+	}
+	*d = parsed
+	return nil
+}
+
+func (d Decimal) MarshalText() ([]byte, error) {
+	return []byte(d.String()), nil
+}
+
+func (d *Decimal) UnmarshalText(text []byte) error {
+	parsed, err := Parse(string(text))
+	if err != nil {
+		return err
+	}
+	*d = parsed
+	return nil
+}
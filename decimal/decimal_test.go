@@ -0,0 +1,168 @@
+package decimal
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+func TestParseAndString(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{"0", "0"},
+		{"1.23", "1.23"},
+		{"0.00100000", "0.00100000"},
+		{"-0.5", "-0.5"},
+		{"+12", "12"},
+		{".5", "0.5"},
+		{"1e-8", "0.00000001"},
+		{"1.5E3", "1500"},
+		{"123456789012345678901234567890.123456789", "123456789012345678901234567890.123456789"},
+		{"1e64", "1" + strings.Repeat("0", 64)},
+		{"1e-64", "0." + strings.Repeat("0", 63) + "1"},
+	}
+	for _, test := range tests {
+		d, err := Parse(test.input)
+		if err != nil {
+			t.Fatalf("failed to parse %s: %v", test.input, err)
+		}
+		if d.String() != test.expected {
+			t.Errorf("expected %s for %s, got %s", test.expected, test.input, d.String())
+		}
+	}
+
+	for _, input := range []string{"", "abc", "1.2.3", "--1", "1e", "NaN",
+		"1e65", "1e-65", "1e99999999", "1e-2147483648", "1e2147483647",
+		"0." + strings.Repeat("0", 64) + "1"} {
+		if _, err := Parse(input); err == nil {
+			t.Errorf("expected error parsing %q", input)
+		}
+	}
+}
+
+func TestArithmetic(t *testing.T) {
+	a := MustParse("0.1")
+	b := MustParse("0.2")
+	if sum := a.Add(b); sum.String() != "0.3" {
+		t.Errorf("expected 0.3, got %s", sum)
+	}
+	if diff := a.Sub(b); diff.String() != "-0.1" {
+		t.Errorf("expected -0.1, got %s", diff)
+	}
+	if product := MustParse("0.00123000").Mul(MustParse("25.5")); !product.Equal(MustParse("0.0313650")) {
+		t.Errorf("expected 0.031365, got %s", product)
+	}
+	if quotient := NewFromInt(1).Div(NewFromInt(3), 8); quotient.String() != "0.33333333" {
+		t.Errorf("expected 0.33333333, got %s", quotient)
+	}
+	if quotient := NewFromInt(-2).Div(NewFromInt(3), 2); quotient.String() != "-0.67" {
+		t.Errorf("expected -0.67, got %s", quotient)
+	}
+	if !MustParse("1.0").Equal(MustParse("1.00")) {
+		t.Errorf("expected 1.0 to equal 1.00")
+	}
+	if !MustParse("0.001").LessThan(MustParse("0.01")) {
+		t.Errorf("expected 0.001 < 0.01")
+	}
+	if !Zero.IsZero() || Zero.String() != "0" {
+		t.Errorf("unexpected zero value: %s", Zero)
+	}
+}
+
+func TestRounding(t *testing.T) {
+	d := MustParse("1.2345")
+	if r := d.Round(3); r.String() != "1.235" {
+		t.Errorf("expected 1.235, got %s", r)
+	}
+	if r := d.Truncate(2); r.String() != "1.23" {
+		t.Errorf("expected 1.23, got %s", r)
+	}
+	if r := d.Neg().Floor(2); r.String() != "-1.24" {
+		t.Errorf("expected -1.24, got %s", r)
+	}
+	if r := d.Ceil(1); r.String() != "1.3" {
+		t.Errorf("expected 1.3, got %s", r)
+	}
+	for _, test := range []struct {
+		value    Decimal
+		expected string
+	}{
+		{MustParse("123").Round(-1), "120"},
+		{MustParse("125.5").Round(-1), "130"},
+		{MustParse("-125").Round(-1), "-130"},
+		{MustParse("199.99").Truncate(-2), "100"},
+		{MustParse("-101").Floor(-2), "-200"},
+		{MustParse("101").Ceil(-2), "200"},
+		{MustParse("49").Round(-2), "0"},
+	} {
+		if test.value.String() != test.expected || test.value.Scale() != 0 {
+			t.Errorf("expected %s with scale 0, got %s with scale %d",
+				test.expected, test.value, test.value.Scale())
+		}
+	}
+	if s := MustParse("2").StringFixed(4); s != "2.0000" {
+		t.Errorf("expected 2.0000, got %s", s)
+	}
+	if n := MustParse("1.2300").Normalize(); n.String() != "1.23" {
+		t.Errorf("expected 1.23, got %s", n)
+	}
+}
+
+func TestStepRounding(t *testing.T) {
+	step := MustParse("0.00100000")
+	quantity := MustParse("1.23456")
+	if r := quantity.FloorToStep(step); r.String() != "1.23400000" {
+		t.Errorf("expected 1.23400000, got %s", r)
+	}
+	if r := quantity.CeilToStep(step); r.String() != "1.23500000" {
+		t.Errorf("expected 1.23500000, got %s", r)
+	}
+	tick := MustParse("0.05")
+	if r := MustParse("10.025").RoundToStep(tick); r.String() != "10.05" {
+		t.Errorf("expected 10.05, got %s", r)
+	}
+	if r := MustParse("10.024").RoundToStep(tick); r.String() != "10.00" {
+		t.Errorf("expected 10.00, got %s", r)
+	}
+	if !MustParse("10.05").IsMultipleOf(tick) || MustParse("10.051").IsMultipleOf(tick) {
+		t.Errorf("unexpected IsMultipleOf result")
+	}
+	if r := quantity.FloorToStep(Zero); !r.Equal(quantity) {
+		t.Errorf("expected zero step to return value unchanged")
+	}
+}
+
+func TestJSON(t *testing.T) {
+	var value struct {
+		Price    Decimal `json:"price"`
+		Quantity Decimal `json:"qty"`
+		Missing  Decimal `json:"missing"`
+	}
+	input := `{"price":"0.00012340","qty":1.5,"missing":null}`
+	if err := json.Unmarshal([]byte(input), &value); err != nil {
+		t.Fatal(err)
+	}
+	if value.Price.String() != "0.00012340" || value.Quantity.String() != "1.5" {
+		t.Errorf("unexpected values: %+v", value)
+	}
+	buf, err := json.Marshal(value)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := `{"price":"0.00012340","qty":"1.5","missing":"0"}`
+	if string(buf) != expected {
+		t.Errorf("expected %s, got %s", expected, buf)
+	}
+}
+
+func TestNewFromFloat64(t *testing.T) {
+	a, b := 0.1, 0.2
+	if d := NewFromFloat64(a + b); d.String() != "0.30000000000000004" {
+		t.Errorf("unexpected value: %s", d)
+	}
+	if d := NewFromFloat64(0.3); d.String() != "0.3" {
+		t.Errorf("unexpected value: %s", d)
+	}
+}
@@ -0,0 +1,207 @@
+// The MIT License (MIT)
+//
+// Copyright (c) 2018 Cranky Kernel
+//
+// Permission is hereby granted, free of charge, to any person
+// obtaining a copy of this software and associated documentation
+// files (the "Software"), to deal in the Software without
+// restriction, including without limitation the rights to use, copy,
+// modify, merge, publish, distribute, sublicense, and/or sell copies
+// of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be
+// included in all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND,
+// EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF
+// MERCHANTABILITY, FITNESS FOR A PARTICULAR PURPOSE AND
+// NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT HOLDERS
+// BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER IN AN
+// ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN
+// CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package gdax
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"time"
+)
+
+const API_ROOT = "https://api.exchange.coinbase.com"
+
+// The API rejects requests without a user agent.
+const USER_AGENT = "cryptotrader"
+
+type apiClientAuth struct {
+	Key        string
+	Secret     string
+	Passphrase string
+}
+
+type ApiClient struct {
+	auth *apiClientAuth
+}
+
+func NewApiClient() *ApiClient {
+	return &ApiClient{}
+}
+
+func NewAuthenticatedApiClient(key string, secret string, passphrase string) *ApiClient {
+	return &ApiClient{
+		auth: &apiClientAuth{
+			Key:        key,
+			Secret:     secret,
+			Passphrase: passphrase,
+		},
+	}
+}
+
+type ApiError struct {
+	StatusCode int
+	Message    string `json:"message"`
+}
+
+func (e *ApiError) Error() string {
+	return fmt.Sprintf("%d: %s", e.StatusCode, e.Message)
+}
+
+func newApiErrorFromResponse(r *http.Response) *ApiError {
+	apiError := &ApiError{
+		StatusCode: r.StatusCode,
+	}
+	body, _ := ioutil.ReadAll(r.Body)
+	if err := json.Unmarshal(body, apiError); err != nil || apiError.Message == "" {
+		apiError.Message = string(body)
+	}
+	return apiError
+}
+
+// Get performs a GET request with the params encoded in the query string.
+func (c *ApiClient) Get(endpoint string, params map[string]interface{}) (*http.Response, error) {
+	return c.do("GET", endpoint, params)
+}
+
+// Post performs a POST request with the params encoded as a JSON body.
+func (c *ApiClient) Post(endpoint string, params map[string]interface{}) (*http.Response, error) {
+	return c.do("POST", endpoint, params)
+}
+
+// Delete performs a DELETE request with the params encoded in the query
+// string.
+func (c *ApiClient) Delete(endpoint string, params map[string]interface{}) (*http.Response, error) {
+	return c.do("DELETE", endpoint, params)
+}
+
+func (c *ApiClient) do(method string, endpoint string, params map[string]interface{}) (*http.Response, error) {
+	requestPath := endpoint
+	body := []byte{}
+
+	if method == "POST" {
+		if params == nil {
+			params = map[string]interface{}{}
+		}
+		buf, err := json.Marshal(params)
+		if err != nil {
+			return nil, err
+		}
+		body = buf
+	} else if queryString := buildQueryString(params); queryString != "" {
+		requestPath = fmt.Sprintf("%s?%s", endpoint, queryString)
+	}
+
+	var reader io.Reader
+	if len(body) > 0 {
+		reader = bytes.NewReader(body)
+	}
+	request, err := http.NewRequest(method,
+		fmt.Sprintf("%s%s", API_ROOT, requestPath), reader)
+	if err != nil {
+		return nil, err
+	}
+	request.Header.Set("User-Agent", USER_AGENT)
+	if len(body) > 0 {
+		request.Header.Set("Content-Type", "application/json")
+	}
+
+	if c.auth != nil {
+		timestamp := fmt.Sprintf("%d", time.Now().Unix())
+		signature, err := c.sign(timestamp, method, requestPath, body)
+		if err != nil {
+			return nil, err
+		}
+		request.Header.Set("CB-ACCESS-KEY", c.auth.Key)
+		request.Header.Set("CB-ACCESS-SIGN", signature)
+		request.Header.Set("CB-ACCESS-TIMESTAMP", timestamp)
+		request.Header.Set("CB-ACCESS-PASSPHRASE", c.auth.Passphrase)
+	}
+
+	return http.DefaultClient.Do(request)
+}
+
+// sign computes the CB-ACCESS-SIGN header: the base64 encoded HMAC-SHA256 of
+// timestamp + method + request path + body, keyed with the base64 decoded
+// secret.
+func (c *ApiClient) sign(timestamp string, method string, requestPath string, body []byte) (string, error) {
+	secret, err := base64.StdEncoding.DecodeString(c.auth.Secret)
+	if err != nil {
+		return "", fmt.Errorf("failed to decode api secret: %v", err)
+	}
+	mac := hmac.New(sha256.New, secret)
+	mac.Write([]byte(timestamp + method + requestPath))
+	mac.Write(body)
+	return base64.StdEncoding.EncodeToString(mac.Sum(nil)), nil
+}
+
+func (c *ApiClient) getAndDecode(endpoint string, params map[string]interface{}, response interface{}) error {
+	httpResponse, err := c.Get(endpoint, params)
+	if err != nil {
+		return err
+	}
+	defer httpResponse.Body.Close()
+	if httpResponse.StatusCode != http.StatusOK {
+		return newApiErrorFromResponse(httpResponse)
+	}
+	return json.NewDecoder(httpResponse.Body).Decode(response)
+}
+
+type Product struct {
+	Id              string  `json:"id"`
+	BaseCurrency    string  `json:"base_currency"`
+	QuoteCurrency   string  `json:"quote_currency"`
+	BaseIncrement   float64 `json:"base_increment,string"`
+	QuoteIncrement  float64 `json:"quote_increment,string"`
+	MinMarketFunds  float64 `json:"min_market_funds,string"`
+	DisplayName     string  `json:"display_name"`
+	Status          string  `json:"status"`
+	StatusMessage   string  `json:"status_message"`
+	PostOnly        bool    `json:"post_only"`
+	LimitOnly       bool    `json:"limit_only"`
+	CancelOnly      bool    `json:"cancel_only"`
+	TradingDisabled bool    `json:"trading_disabled"`
+}
+
+// Products returns the available currency pairs for trading.
+func (c *ApiClient) Products() ([]Product, error) {
+	var products []Product
+	err := c.getAndDecode("/products", nil, &products)
+	return products, err
+}
+
+func buildQueryString(params map[string]interface{}) string {
+	values := url.Values{}
+	for key, value := range params {
+		values.Set(key, fmt.Sprintf("%v", value))
+	}
+	return values.Encode()
+}
@@ -0,0 +1,227 @@
+// The MIT License (MIT)
+//
+// Copyright (c) 2018 Cranky Kernel
+//
+// Permission is hereby granted, free of charge, to any person
+// obtaining a copy of this software and associated documentation
+// files (the "Software"), to deal in the Software without
+// restriction, including without limitation the rights to use, copy,
+// modify, merge, publish, distribute, sublicense, and/or sell copies
+// of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be
+// included in all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND,
+// EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF
+// MERCHANTABILITY, FITNESS FOR A PARTICULAR PURPOSE AND
+// NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT HOLDERS
+// BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER IN AN
+// ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN
+// CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package gdax
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"gitlab.com/crankykernel/cryptotrader/core"
+)
+
+// Exchange adapts an ApiClient to the core.Exchange interface. Symbols are
+// GDAX product IDs (ie: BTC-USD).
+type Exchange struct {
+	client *ApiClient
+}
+
+func NewExchange(client *ApiClient) *Exchange {
+	return &Exchange{
+		client: client,
+	}
+}
+
+func (e *Exchange) Name() string {
+	return "GDAX"
+}
+
+func (e *Exchange) GetSymbols() ([]core.Symbol, error) {
+	products, err := e.client.Products()
+	if err != nil {
+		return nil, err
+	}
+	symbols := []core.Symbol{}
+	for _, product := range products {
+		symbols = append(symbols, core.Symbol{
+			Name:       product.Id,
+			BaseAsset:  product.BaseCurrency,
+			QuoteAsset: product.QuoteCurrency,
+		})
+	}
+	return symbols, nil
+}
+
+// GetTickers returns the ticker for each symbol. GDAX only provides a per
+// product ticker so if no symbols are given every product is requested.
+func (e *Exchange) GetTickers(symbols ...string) ([]core.Ticker, error) {
+	if len(symbols) == 0 {
+		products, err := e.client.Products()
+		if err != nil {
+			return nil, err
+		}
+		for _, product := range products {
+			symbols = append(symbols, product.Id)
+		}
+	}
+	tickers := []core.Ticker{}
+	for _, symbol := range symbols {
+		ticker, err := e.client.Ticker(symbol)
+		if err != nil {
+			return nil, err
+		}
+		tickers = append(tickers, core.Ticker{
+			Exchange:  e.Name(),
+			Symbol:    symbol,
+			Timestamp: ticker.Time,
+			Last:      ticker.Price,
+			Bid:       ticker.Bid,
+			Ask:       ticker.Ask,
+			Volume:    ticker.Volume,
+		})
+	}
+	return tickers, nil
+}
+
+func (e *Exchange) GetBalances() ([]core.Balance, error) {
+	accounts, err := e.client.Accounts()
+	if err != nil {
+		return nil, err
+	}
+	balances := []core.Balance{}
+	for _, account := range accounts {
+		if account.Balance == 0 {
+			continue
+		}
+		balances = append(balances, core.Balance{
+			Asset:  account.Currency,
+			Free:   account.Available,
+			Locked: account.Hold,
+		})
+	}
+	return balances, nil
+}
+
+func (e *Exchange) GetOpenOrders(symbol string) ([]core.Order, error) {
+	response, err := e.client.Orders(symbol)
+	if err != nil {
+		return nil, err
+	}
+	orders := []core.Order{}
+	for _, order := range response {
+		orders = append(orders, e.toCoreOrder(order))
+	}
+	return orders, nil
+}
+
+func (e *Exchange) PlaceOrder(request core.OrderRequest) (*core.Order, error) {
+	response, err := e.client.PlaceOrder(OrderParameters{
+		ProductId: request.Symbol,
+		Side:      strings.ToLower(string(request.Side)),
+		Type:      strings.ToLower(string(request.Type)),
+		Price:     request.Price,
+		Size:      request.Quantity,
+		ClientOid: request.ClientOrderId,
+	})
+	if err != nil {
+		return nil, err
+	}
+	order := e.toCoreOrder(*response)
+	return &order, nil
+}
+
+func (e *Exchange) CancelOrder(symbol string, orderId string) error {
+	return e.client.CancelOrder(orderId)
+}
+
+func (e *Exchange) GetTrades(symbol string) ([]core.Trade, error) {
+	fills, err := e.client.Fills(symbol)
+	if err != nil {
+		return nil, err
+	}
+	trades := []core.Trade{}
+	for _, fill := range fills {
+		feeAsset := ""
+		if parts := strings.SplitN(fill.ProductId, "-", 2); len(parts) == 2 {
+			feeAsset = parts[1]
+		}
+		trades = append(trades, core.Trade{
+			Exchange:  e.Name(),
+			Symbol:    fill.ProductId,
+			TradeId:   fmt.Sprintf("%d", fill.TradeId),
+			OrderId:   fill.OrderId,
+			Side:      core.OrderSide(strings.ToUpper(fill.Side)),
+			Price:     fill.Price,
+			Quantity:  fill.Size,
+			Fee:       fill.Fee,
+			FeeAsset:  feeAsset,
+			Timestamp: fill.CreatedAt,
+		})
+	}
+	return trades, nil
+}
+
+func (e *Exchange) GetTransfers(asset string) ([]core.Transfer, error) {
+	response, err := e.client.Transfers()
+	if err != nil {
+		return nil, err
+	}
+	transfers := []core.Transfer{}
+	for _, transfer := range response {
+		if asset != "" && !strings.EqualFold(asset, transfer.Currency) {
+			continue
+		}
+		transferType := core.TransferTypeDeposit
+		if transfer.Type == "withdraw" {
+			transferType = core.TransferTypeWithdrawal
+		}
+		status := "PENDING"
+		if transfer.CompletedAt != nil {
+			status = "COMPLETED"
+		} else if transfer.CanceledAt != nil {
+			status = "CANCELED"
+		}
+		fee, _ := strconv.ParseFloat(transfer.Details.Fee, 64)
+		transfers = append(transfers, core.Transfer{
+			Exchange:   e.Name(),
+			Asset:      transfer.Currency,
+			TransferId: transfer.Id,
+			Type:       transferType,
+			Status:     status,
+			Amount:     transfer.Amount,
+			Fee:        fee,
+			Address:    transfer.Details.CryptoAddress,
+			TxId:       transfer.Details.CryptoTransactionHash,
+			Timestamp:  transfer.CreatedAt,
+		})
+	}
+	return transfers, nil
+}
+
+func (e *Exchange) toCoreOrder(order Order) core.Order {
+	return core.Order{
+		Exchange:       e.Name(),
+		Symbol:         order.ProductId,
+		OrderId:        order.Id,
+		ClientOrderId:  order.ClientOid,
+		Side:           core.OrderSide(strings.ToUpper(order.Side)),
+		Type:           core.OrderType(strings.ToUpper(order.Type)),
+		Status:         strings.ToUpper(order.Status),
+		Price:          order.Price,
+		Quantity:       order.Size,
+		FilledQuantity: order.FilledSize,
+		Timestamp:      order.CreatedAt,
+	}
+}
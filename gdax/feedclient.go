@@ -0,0 +1,200 @@
+// The MIT License (MIT)
+//
+// Copyright (c) 2018 Cranky Kernel
+//
+// Permission is hereby granted, free of charge, to any person
+// obtaining a copy of this software and associated documentation
+// files (the "Software"), to deal in the Software without
+// restriction, including without limitation the rights to use, copy,
+// modify, merge, publish, distribute, sublicense, and/or sell copies
+// of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be
+// included in all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND,
+// EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF
+// MERCHANTABILITY, FITNESS FOR A PARTICULAR PURPOSE AND
+// NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT HOLDERS
+// BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER IN AN
+// ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN
+// CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package gdax
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/gorilla/websocket"
+)
+
+const FEED_URL = "wss://ws-feed.exchange.coinbase.com"
+
+type Channel struct {
+	Name       string   `json:"name"`
+	ProductIds []string `json:"product_ids,omitempty"`
+}
+
+func TickerChannel(productIds []string) Channel {
+	return Channel{
+		Name:       "ticker",
+		ProductIds: productIds,
+	}
+}
+
+func HeartbeatChannel(productIds []string) Channel {
+	return Channel{
+		Name:       "heartbeat",
+		ProductIds: productIds,
+	}
+}
+
+type SubscribeMessage struct {
+	Type     string    `json:"type"`
+	Channels []Channel `json:"channels"`
+}
+
+type TickerMessage struct {
+	Type        string    `json:"type"`
+	Sequence    int64     `json:"sequence"`
+	TradeId     int64     `json:"trade_id"`
+	ProductId   string    `json:"product_id"`
+	Time        time.Time `json:"time"`
+	Price       float64   `json:"price,string"`
+	Side        string    `json:"side"`
+	LastSize    float64   `json:"last_size,string"`
+	BestBid     float64   `json:"best_bid,string"`
+	BestBidSize float64   `json:"best_bid_size,string"`
+	BestAsk     float64   `json:"best_ask,string"`
+	BestAskSize float64   `json:"best_ask_size,string"`
+	Open24h     float64   `json:"open_24h,string"`
+	High24h     float64   `json:"high_24h,string"`
+	Low24h      float64   `json:"low_24h,string"`
+	Volume24h   float64   `json:"volume_24h,string"`
+	Volume30d   float64   `json:"volume_30d,string"`
+}
+
+type HeartbeatMessage struct {
+	Type        string    `json:"type"`
+	Sequence    int64     `json:"sequence"`
+	LastTradeId int64     `json:"last_trade_id"`
+	ProductId   string    `json:"product_id"`
+	Time        time.Time `json:"time"`
+}
+
+type ErrorMessage struct {
+	Type    string `json:"type"`
+	Message string `json:"message"`
+	Reason  string `json:"reason"`
+}
+
+type SubscriptionsMessage struct {
+	Type     string    `json:"type"`
+	Channels []Channel `json:"channels"`
+}
+
+// FeedMessage is a decoded message from the websocket feed. Only the field
+// for the message type will be set.
+type FeedMessage struct {
+	Type string `json:"type"`
+
+	Ticker        *TickerMessage        `json:"ticker,omitempty"`
+	Heartbeat     *HeartbeatMessage     `json:"heartbeat,omitempty"`
+	Error         *ErrorMessage         `json:"error,omitempty"`
+	Subscriptions *SubscriptionsMessage `json:"subscriptions,omitempty"`
+
+	// For a message type that is unknown, decode into an interface{}.
+	UnknownData interface{} `json:"unknown,omitempty"`
+
+	Bytes []byte `json:"-"`
+}
+
+func (m *FeedMessage) UnmarshalJSON(b []byte) error {
+	m.Bytes = b
+
+	var header struct {
+		Type string `json:"type"`
+	}
+	if err := json.Unmarshal(b, &header); err != nil {
+		return err
+	}
+	m.Type = header.Type
+
+	switch header.Type {
+	case "ticker":
+		m.Ticker = &TickerMessage{}
+		return json.Unmarshal(b, m.Ticker)
+	case "heartbeat":
+		m.Heartbeat = &HeartbeatMessage{}
+		return json.Unmarshal(b, m.Heartbeat)
+	case "error":
+		m.Error = &ErrorMessage{}
+		return json.Unmarshal(b, m.Error)
+	case "subscriptions":
+		m.Subscriptions = &SubscriptionsMessage{}
+		return json.Unmarshal(b, m.Subscriptions)
+	default:
+		return json.Unmarshal(b, &m.UnknownData)
+	}
+}
+
+type FeedClient struct {
+	conn *websocket.Conn
+}
+
+func NewFeedClient() *FeedClient {
+	return &FeedClient{}
+}
+
+func (c *FeedClient) Connect() error {
+	conn, httpResponse, err := websocket.DefaultDialer.Dial(FEED_URL, nil)
+	if err != nil {
+		return err
+	}
+	if httpResponse.StatusCode != http.StatusSwitchingProtocols {
+		conn.Close()
+		return fmt.Errorf("%s", httpResponse.Status)
+	}
+	c.conn = conn
+	return nil
+}
+
+func (c *FeedClient) Close() {
+	if c.conn != nil {
+		c.conn.Close()
+	}
+}
+
+// Subscribe to one or more channels. The server will respond with a
+// subscriptions message, or an error message if the subscribe failed.
+func (c *FeedClient) Subscribe(channels ...Channel) error {
+	return c.conn.WriteJSON(SubscribeMessage{
+		Type:     "subscribe",
+		Channels: channels,
+	})
+}
+
+func (c *FeedClient) Unsubscribe(channels ...Channel) error {
+	return c.conn.WriteJSON(SubscribeMessage{
+		Type:     "unsubscribe",
+		Channels: channels,
+	})
+}
+
+// Next reads and decodes the next message from the feed.
+func (c *FeedClient) Next() (*FeedMessage, error) {
+	_, body, err := c.conn.ReadMessage()
+	if err != nil {
+		return nil, err
+	}
+	var message FeedMessage
+	if err := json.Unmarshal(body, &message); err != nil {
+		return nil, err
+	}
+	return &message, nil
+}
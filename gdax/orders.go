@@ -0,0 +1,192 @@
+// The MIT License (MIT)
+//
+// Copyright (c) 2018 Cranky Kernel
+//
+// Permission is hereby granted, free of charge, to any person
+// obtaining a copy of this software and associated documentation
+// files (the "Software"), to deal in the Software without
+// restriction, including without limitation the rights to use, copy,
+// modify, merge, publish, distribute, sublicense, and/or sell copies
+// of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be
+// included in all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND,
+// EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF
+// MERCHANTABILITY, FITNESS FOR A PARTICULAR PURPOSE AND
+// NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT HOLDERS
+// BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER IN AN
+// ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN
+// CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package gdax
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+)
+
+// GET /products/<product-id>/ticker
+type ProductTicker struct {
+	TradeId int64     `json:"trade_id"`
+	Price   float64   `json:"price,string"`
+	Size    float64   `json:"size,string"`
+	Bid     float64   `json:"bid,string"`
+	Ask     float64   `json:"ask,string"`
+	Volume  float64   `json:"volume,string"`
+	Time    time.Time `json:"time"`
+}
+
+func (c *ApiClient) Ticker(productId string) (*ProductTicker, error) {
+	var ticker ProductTicker
+	endpoint := fmt.Sprintf("/products/%s/ticker", productId)
+	if err := c.getAndDecode(endpoint, nil, &ticker); err != nil {
+		return nil, err
+	}
+	return &ticker, nil
+}
+
+// GET /accounts
+type Account struct {
+	Id        string  `json:"id"`
+	Currency  string  `json:"currency"`
+	Balance   float64 `json:"balance,string"`
+	Available float64 `json:"available,string"`
+	Hold      float64 `json:"hold,string"`
+}
+
+func (c *ApiClient) Accounts() ([]Account, error) {
+	var accounts []Account
+	err := c.getAndDecode("/accounts", nil, &accounts)
+	return accounts, err
+}
+
+type Order struct {
+	Id         string    `json:"id"`
+	ClientOid  string    `json:"client_oid,omitempty"`
+	ProductId  string    `json:"product_id"`
+	Side       string    `json:"side"`
+	Type       string    `json:"type"`
+	Price      float64   `json:"price,string,omitempty"`
+	Size       float64   `json:"size,string,omitempty"`
+	FilledSize float64   `json:"filled_size,string,omitempty"`
+	Status     string    `json:"status"`
+	CreatedAt  time.Time `json:"created_at"`
+}
+
+// Orders returns the open orders for a product, or all products if
+// productId is empty.
+func (c *ApiClient) Orders(productId string) ([]Order, error) {
+	params := map[string]interface{}{
+		"status": "open",
+	}
+	if productId != "" {
+		params["product_id"] = productId
+	}
+	var orders []Order
+	err := c.getAndDecode("/orders", params, &orders)
+	return orders, err
+}
+
+type OrderParameters struct {
+	ProductId string
+	Side      string
+	Type      string
+	Price     float64
+	Size      float64
+	ClientOid string
+}
+
+func (c *ApiClient) PlaceOrder(order OrderParameters) (*Order, error) {
+	params := map[string]interface{}{
+		"product_id": order.ProductId,
+		"side":       order.Side,
+		"type":       order.Type,
+		"size":       fmt.Sprintf("%.8f", order.Size),
+	}
+	if order.Type == "limit" {
+		params["price"] = fmt.Sprintf("%.8f", order.Price)
+	}
+	if order.ClientOid != "" {
+		params["client_oid"] = order.ClientOid
+	}
+
+	httpResponse, err := c.Post("/orders", params)
+	if err != nil {
+		return nil, err
+	}
+	defer httpResponse.Body.Close()
+	if httpResponse.StatusCode != http.StatusOK {
+		return nil, newApiErrorFromResponse(httpResponse)
+	}
+	var response Order
+	if err := json.NewDecoder(httpResponse.Body).Decode(&response); err != nil {
+		return nil, err
+	}
+	return &response, nil
+}
+
+func (c *ApiClient) CancelOrder(orderId string) error {
+	httpResponse, err := c.Delete(fmt.Sprintf("/orders/%s", orderId), nil)
+	if err != nil {
+		return err
+	}
+	defer httpResponse.Body.Close()
+	if httpResponse.StatusCode != http.StatusOK {
+		return newApiErrorFromResponse(httpResponse)
+	}
+	return nil
+}
+
+// GET /fills
+type Fill struct {
+	TradeId   int64     `json:"trade_id"`
+	ProductId string    `json:"product_id"`
+	OrderId   string    `json:"order_id"`
+	Side      string    `json:"side"`
+	Price     float64   `json:"price,string"`
+	Size      float64   `json:"size,string"`
+	Fee       float64   `json:"fee,string"`
+	Liquidity string    `json:"liquidity"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// Fills returns the most recent fills for a product, or all products if
+// productId is empty.
+func (c *ApiClient) Fills(productId string) ([]Fill, error) {
+	params := map[string]interface{}{}
+	if productId != "" {
+		params["product_id"] = productId
+	}
+	var fills []Fill
+	err := c.getAndDecode("/fills", params, &fills)
+	return fills, err
+}
+
+// GET /transfers
+type Transfer struct {
+	Id          string     `json:"id"`
+	Type        string     `json:"type"`
+	Currency    string     `json:"currency"`
+	Amount      float64    `json:"amount,string"`
+	CreatedAt   time.Time  `json:"created_at"`
+	CompletedAt *time.Time `json:"completed_at"`
+	CanceledAt  *time.Time `json:"canceled_at"`
+	Details     struct {
+		CryptoAddress         string `json:"crypto_address"`
+		CryptoTransactionHash string `json:"crypto_transaction_hash"`
+		Fee                   string `json:"fee"`
+	} `json:"details"`
+}
+
+// Transfers returns the deposits and withdrawals for the profile.
+func (c *ApiClient) Transfers() ([]Transfer, error) {
+	var transfers []Transfer
+	err := c.getAndDecode("/transfers", nil, &transfers)
+	return transfers, err
+}
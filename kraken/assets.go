@@ -0,0 +1,128 @@
+// The MIT License (MIT)
+//
+// Copyright (c) 2018 Cranky Kernel
+//
+// Permission is hereby granted, free of charge, to any person
+// obtaining a copy of this software and associated documentation
+// files (the "Software"), to deal in the Software without
+// restriction, including without limitation the rights to use, copy,
+// modify, merge, publish, distribute, sublicense, and/or sell copies
+// of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be
+// included in all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND,
+// EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF
+// MERCHANTABILITY, FITNESS FOR A PARTICULAR PURPOSE AND
+// NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT HOLDERS
+// BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER IN AN
+// ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN
+// CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package kraken
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"gitlab.com/crankykernel/cryptotrader/core"
+)
+
+// Quote assets used to split pair names that are not made up of two 4
+// character X/Z prefixed asset names. Longer names are checked first.
+var quoteAssets = []string{
+	"USDT",
+	"USDC",
+	"XXBT",
+	"XETH",
+	"ZUSD",
+	"ZEUR",
+	"ZCAD",
+	"ZGBP",
+	"ZJPY",
+	"XBT",
+	"ETH",
+	"USD",
+	"EUR",
+	"CAD",
+	"GBP",
+	"JPY",
+	"CHF",
+	"AUD",
+	"DAI",
+}
+
+// NormalizeAssetName converts a Kraken asset name to its common name, for
+// example XXBT -> BTC and ZUSD -> USD. Once the X/Z class prefix has been
+// removed, names such as XBT are resolved by core.NormalizeAsset.
+func NormalizeAssetName(name string) string {
+	name = strings.ToUpper(name)
+	if len(name) == 4 && (name[0] == 'X' || name[0] == 'Z') {
+		name = name[1:]
+	}
+	return core.NormalizeAsset(name)
+}
+
+// SplitPairName splits a Kraken pair name into its base and quote assets
+// without normalizing them.
+func SplitPairName(pair string) (base string, quote string, err error) {
+	pair = strings.ToUpper(pair)
+	if len(pair) == 8 && isClassPrefix(pair[0]) && isClassPrefix(pair[4]) {
+		return pair[0:4], pair[4:], nil
+	}
+	for _, quote := range quoteAssets {
+		if strings.HasSuffix(pair, quote) && len(pair) > len(quote) {
+			return pair[0 : len(pair)-len(quote)], quote, nil
+		}
+	}
+	return "", "", fmt.Errorf("unable to split pair name: %s", pair)
+}
+
+// GetNormalizePairName converts a Kraken pair name to a normalized name of
+// the form BASE/QUOTE, for example XXBTZUSD -> BTC/USD. If the pair can not
+// be split the name is returned unmodified.
+func GetNormalizePairName(pair string) string {
+	base, quote, err := SplitPairName(pair)
+	if err != nil {
+		return pair
+	}
+	return fmt.Sprintf("%s/%s", NormalizeAssetName(base), NormalizeAssetName(quote))
+}
+
+func isClassPrefix(c byte) bool {
+	return c == 'X' || c == 'Z'
+}
+
+// GET /0/public/AssetPairs
+type AssetPair struct {
+	Name    string `json:"-"`
+	AltName string `json:"altname"`
+	WsName  string `json:"wsname"`
+	Base    string `json:"base"`
+	Quote   string `json:"quote"`
+}
+
+// AssetPairs returns the tradable asset pairs sorted by name. Dark pool
+// pairs (ie: XXBTZUSD.d) are not included.
+func (c *Client) AssetPairs() ([]AssetPair, error) {
+	var result map[string]AssetPair
+	if err := c.getAndDecode("/0/public/AssetPairs", nil, &result); err != nil {
+		return nil, err
+	}
+	pairs := []AssetPair{}
+	for name, pair := range result {
+		if strings.HasSuffix(name, ".d") {
+			continue
+		}
+		pair.Name = name
+		pairs = append(pairs, pair)
+	}
+	sort.Slice(pairs, func(i, j int) bool {
+		return pairs[i].Name < pairs[j].Name
+	})
+	return pairs, nil
+}
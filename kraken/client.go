@@ -0,0 +1,198 @@
+// The MIT License (MIT)
+//
+// Copyright (c) 2018 Cranky Kernel
+//
+// Permission is hereby granted, free of charge, to any person
+// obtaining a copy of this software and associated documentation
+// files (the "Software"), to deal in the Software without
+// restriction, including without limitation the rights to use, copy,
+// modify, merge, publish, distribute, sublicense, and/or sell copies
+// of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be
+// included in all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND,
+// EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF
+// MERCHANTABILITY, FITNESS FOR A PARTICULAR PURPOSE AND
+// NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT HOLDERS
+// BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER IN AN
+// ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN
+// CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package kraken
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/sha256"
+	"crypto/sha512"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"gitlab.com/crankykernel/cryptotrader/util"
+)
+
+const API_ROOT = "https://api.kraken.com"
+
+type Client struct {
+	apiKey    string
+	apiSecret string
+
+	nonceLock sync.Mutex
+	lastNonce int64
+}
+
+func NewClient(apiKey string, apiSecret string) *Client {
+	return &Client{
+		apiKey:    apiKey,
+		apiSecret: apiSecret,
+	}
+}
+
+// ApiError is returned when Kraken responds with a non-empty error list.
+type ApiError struct {
+	Errors []string
+}
+
+func (e *ApiError) Error() string {
+	return strings.Join(e.Errors, "; ")
+}
+
+// Response is the envelope every Kraken REST response is wrapped in.
+type Response struct {
+	Error  []string        `json:"error"`
+	Result json.RawMessage `json:"result"`
+}
+
+// Get performs a GET request, used for the public endpoints.
+func (c *Client) Get(endpoint string, params map[string]interface{}) (*http.Response, error) {
+	url := fmt.Sprintf("%s%s", API_ROOT, endpoint)
+	if values := buildValues(params); len(values) > 0 {
+		url = fmt.Sprintf("%s?%s", url, values.Encode())
+	}
+
+	request, err := http.NewRequest("GET", url, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return http.DefaultClient.Do(request)
+}
+
+// Post performs a POST request. Requests to the private endpoints are signed
+// with a nonce and the API-Sign header.
+func (c *Client) Post(endpoint string, params map[string]interface{}) (*http.Response, error) {
+	values := buildValues(params)
+
+	private := strings.HasPrefix(endpoint, "/0/private/")
+	if private {
+		values.Set("nonce", c.nonce())
+	}
+
+	body := values.Encode()
+	request, err := http.NewRequest("POST",
+		fmt.Sprintf("%s%s", API_ROOT, endpoint), strings.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	request.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+	if private {
+		signature, err := c.sign(endpoint, values.Get("nonce"), body)
+		if err != nil {
+			return nil, err
+		}
+		request.Header.Set("API-Key", c.apiKey)
+		request.Header.Set("API-Sign", signature)
+	}
+
+	return http.DefaultClient.Do(request)
+}
+
+// sign computes the API-Sign value: HMAC-SHA512 of the URI path and the
+// SHA256 of the nonce and POST data, keyed with the base64 decoded secret.
+func (c *Client) sign(path string, nonce string, body string) (string, error) {
+	secret, err := base64.StdEncoding.DecodeString(c.apiSecret)
+	if err != nil {
+		return "", fmt.Errorf("failed to decode api secret: %v", err)
+	}
+	sha := sha256.Sum256([]byte(nonce + body))
+	mac := hmac.New(sha512.New, secret)
+	mac.Write([]byte(path))
+	mac.Write(sha[:])
+	return base64.StdEncoding.EncodeToString(mac.Sum(nil)), nil
+}
+
+// nonce returns an always increasing nonce based on the current time in
+// milliseconds.
+func (c *Client) nonce() string {
+	c.nonceLock.Lock()
+	defer c.nonceLock.Unlock()
+	nonce := time.Now().UnixNano() / int64(time.Millisecond)
+	if nonce <= c.lastNonce {
+		nonce = c.lastNonce + 1
+	}
+	c.lastNonce = nonce
+	return strconv.FormatInt(nonce, 10)
+}
+
+func (c *Client) getAndDecode(endpoint string, params map[string]interface{}, result interface{}) error {
+	response, err := c.Get(endpoint, params)
+	if err != nil {
+		return err
+	}
+	return decodeResponse(response, result)
+}
+
+func (c *Client) postAndDecode(endpoint string, params map[string]interface{}, result interface{}) error {
+	response, err := c.Post(endpoint, params)
+	if err != nil {
+		return err
+	}
+	return decodeResponse(response, result)
+}
+
+// decodeResponse decodes the response envelope, returning an ApiError if
+// Kraken returned any errors, otherwise the result is decoded into result.
+func decodeResponse(r *http.Response, result interface{}) error {
+	body, err := util.ReadAll(r)
+	if err != nil {
+		return err
+	}
+
+	var response Response
+	if err := json.Unmarshal(body, &response); err != nil {
+		if r.StatusCode >= 400 {
+			return fmt.Errorf("%s: %s", r.Status, string(body))
+		}
+		return err
+	}
+	if len(response.Error) > 0 {
+		return &ApiError{Errors: response.Error}
+	}
+	if result == nil || len(response.Result) == 0 {
+		return nil
+	}
+
+	decoder := json.NewDecoder(bytes.NewReader(response.Result))
+	decoder.UseNumber()
+	return decoder.Decode(result)
+}
+
+func buildValues(params map[string]interface{}) url.Values {
+	values := url.Values{}
+	for key, value := range params {
+		values.Set(key, fmt.Sprintf("%v", value))
+	}
+	return values
+}
@@ -0,0 +1,36 @@
+package kraken
+
+import (
+	"testing"
+)
+
+func TestSign(t *testing.T) {
+	// Example from the Kraken REST API authentication documentation.
+	client := NewClient("",
+		"kQH5HW/8p1uGOVjbgWA7FunAmGO8lsSUXNsu3eow76sz84Q18fWxnyRzBHCd3pd5nE9qa99HAZtuZuj6F1huXg==")
+	signature, err := client.sign("/0/private/AddOrder", "1616492376594",
+		"nonce=1616492376594&ordertype=limit&pair=XBTUSD&price=37500&type=buy&volume=1.25")
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := "4/dpxb3iT4tp/ZCVEwSnEsLxx0bqyhLpdfOpc6fn7OR8+UClSV5n9E6aSS8MPtnRfp32bAb0nmbRn6H8ndwLUQ=="
+	if signature != expected {
+		t.Fatalf("expected %s, got %s", expected, signature)
+	}
+}
+
+func TestGetNormalizePairName(t *testing.T) {
+	tests := map[string]string{
+		"XXBTZUSD": "BTC/USD",
+		"XETHXXBT": "ETH/BTC",
+		"BCHUSD":   "BCH/USD",
+		"EOSETH":   "EOS/ETH",
+		"XXDGXXBT": "DOGE/BTC",
+		"USDTZUSD": "USDT/USD",
+	}
+	for pair, expected := range tests {
+		if normalized := GetNormalizePairName(pair); normalized != expected {
+			t.Errorf("%s: expected %s, got %s", pair, expected, normalized)
+		}
+	}
+}
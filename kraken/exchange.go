@@ -0,0 +1,238 @@
+// The MIT License (MIT)
+//
+// Copyright (c) 2018 Cranky Kernel
+//
+// Permission is hereby granted, free of charge, to any person
+// obtaining a copy of this software and associated documentation
+// files (the "Software"), to deal in the Software without
+// restriction, including without limitation the rights to use, copy,
+// modify, merge, publish, distribute, sublicense, and/or sell copies
+// of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be
+// included in all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND,
+// EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF
+// MERCHANTABILITY, FITNESS FOR A PARTICULAR PURPOSE AND
+// NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT HOLDERS
+// BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER IN AN
+// ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN
+// CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package kraken
+
+import (
+	"strings"
+	"time"
+
+	"gitlab.com/crankykernel/cryptotrader/core"
+)
+
+// Exchange adapts a Client to the core.Exchange interface.
+type Exchange struct {
+	client *Client
+}
+
+func NewExchange(client *Client) *Exchange {
+	return &Exchange{
+		client: client,
+	}
+}
+
+func (e *Exchange) Name() string {
+	return "Kraken"
+}
+
+func (e *Exchange) GetSymbols() ([]core.Symbol, error) {
+	pairs, err := e.client.AssetPairs()
+	if err != nil {
+		return nil, err
+	}
+	symbols := []core.Symbol{}
+	for _, pair := range pairs {
+		symbols = append(symbols, core.Symbol{
+			Name:       pair.Name,
+			BaseAsset:  NormalizeAssetName(pair.Base),
+			QuoteAsset: NormalizeAssetName(pair.Quote),
+			Aliases:    []string{pair.AltName},
+		})
+	}
+	return symbols, nil
+}
+
+func (e *Exchange) GetTickers(symbols ...string) ([]core.Ticker, error) {
+	response, err := e.client.Ticker(symbols...)
+	if err != nil {
+		return nil, err
+	}
+	now := time.Now()
+	tickers := []core.Ticker{}
+	for _, ticker := range response {
+		tickers = append(tickers, core.Ticker{
+			Exchange:  e.Name(),
+			Symbol:    ticker.Pair,
+			Timestamp: now,
+			Last:      ticker.Last,
+			Bid:       ticker.Bid,
+			Ask:       ticker.Ask,
+			Volume:    ticker.Volume24h,
+		})
+	}
+	return tickers, nil
+}
+
+// GetBalances returns the non-zero balances. Kraken does not report the
+// amount held by open orders so the full balance is reported as free.
+func (e *Exchange) GetBalances() ([]core.Balance, error) {
+	response, err := e.client.Balance()
+	if err != nil {
+		return nil, err
+	}
+	balances := []core.Balance{}
+	for asset, balance := range response {
+		if balance == 0 {
+			continue
+		}
+		balances = append(balances, core.Balance{
+			Asset: asset,
+			Free:  balance,
+		})
+	}
+	return balances, nil
+}
+
+func (e *Exchange) GetOpenOrders(symbol string) ([]core.Order, error) {
+	response, err := e.client.OpenOrders()
+	if err != nil {
+		return nil, err
+	}
+	orders := []core.Order{}
+	for _, order := range response {
+		if symbol != "" && !samePair(symbol, order.Description.Pair) {
+			continue
+		}
+		price := order.Description.Price
+		if price == 0 {
+			price = order.Price
+		}
+		orders = append(orders, core.Order{
+			Exchange:       e.Name(),
+			Symbol:         order.Description.Pair,
+			OrderId:        order.TransactionID,
+			Side:           core.OrderSide(strings.ToUpper(order.Description.Type)),
+			Type:           core.OrderType(strings.ToUpper(order.Description.OrderType)),
+			Status:         strings.ToUpper(order.Status),
+			Price:          price,
+			Quantity:       order.Volume,
+			FilledQuantity: order.VolumeExecuted,
+			Timestamp:      order.Timestamp(),
+		})
+	}
+	return orders, nil
+}
+
+func (e *Exchange) PlaceOrder(request core.OrderRequest) (*core.Order, error) {
+	response, err := e.client.AddOrder(AddOrderParameters{
+		Pair:      request.Symbol,
+		Type:      strings.ToLower(string(request.Side)),
+		OrderType: strings.ToLower(string(request.Type)),
+		Price:     request.Price,
+		Volume:    request.Quantity,
+	})
+	if err != nil {
+		return nil, err
+	}
+	order := &core.Order{
+		Exchange:  e.Name(),
+		Symbol:    request.Symbol,
+		Side:      request.Side,
+		Type:      request.Type,
+		Status:    "OPEN",
+		Price:     request.Price,
+		Quantity:  request.Quantity,
+		Timestamp: time.Now(),
+	}
+	if len(response.TransactionIDs) > 0 {
+		order.OrderId = response.TransactionIDs[0]
+	}
+	return order, nil
+}
+
+func (e *Exchange) CancelOrder(symbol string, orderId string) error {
+	return e.client.CancelOrder(orderId)
+}
+
+func (e *Exchange) GetTrades(symbol string) ([]core.Trade, error) {
+	response, err := e.client.TradesHistory()
+	if err != nil {
+		return nil, err
+	}
+	trades := []core.Trade{}
+	for _, trade := range response {
+		if symbol != "" && !samePair(symbol, trade.Pair) {
+			continue
+		}
+		base, quote, _ := SplitPairName(trade.Pair)
+		feeAsset := quote
+		if feeAsset == "" {
+			feeAsset = base
+		}
+		trades = append(trades, core.Trade{
+			Exchange:  e.Name(),
+			Symbol:    trade.Pair,
+			TradeId:   trade.TransactionID,
+			OrderId:   trade.OrderTransactionID,
+			Side:      core.OrderSide(strings.ToUpper(trade.Type)),
+			Price:     trade.Price,
+			Quantity:  trade.Volume,
+			Fee:       trade.Fee,
+			FeeAsset:  feeAsset,
+			Timestamp: trade.Timestamp(),
+		})
+	}
+	return trades, nil
+}
+
+// GetTransfers returns deposits and withdrawals as found in the ledger.
+func (e *Exchange) GetTransfers(asset string) ([]core.Transfer, error) {
+	ledger := NewLedgerService(e.client)
+	transfers := []core.Transfer{}
+	for _, entryType := range []string{"deposit", "withdrawal"} {
+		entries, err := ledger.Ledger(GetLedgerOptions{
+			Type:  entryType,
+			Asset: asset,
+		})
+		if err != nil {
+			return nil, err
+		}
+		for _, entry := range entries {
+			transferType := core.TransferTypeDeposit
+			if entry.Type == "withdrawal" {
+				transferType = core.TransferTypeWithdrawal
+			}
+			amount := entry.Amount
+			if amount < 0 {
+				amount = -amount
+			}
+			transfers = append(transfers, core.Transfer{
+				Exchange:   e.Name(),
+				Asset:      entry.Asset,
+				TransferId: entry.ReferenceID,
+				Type:       transferType,
+				Amount:     amount,
+				Fee:        entry.Fee,
+				Timestamp:  entry.Timestamp,
+			})
+		}
+	}
+	return transfers, nil
+}
+
+// samePair compares two Kraken pair names that may be in different forms,
+// for example XBTUSD and XXBTZUSD.
+func samePair(a string, b string) bool {
+	return GetNormalizePairName(a) == GetNormalizePairName(b)
+}
@@ -0,0 +1,161 @@
+// The MIT License (MIT)
+//
+// Copyright (c) 2018 Cranky Kernel
+//
+// Permission is hereby granted, free of charge, to any person
+// obtaining a copy of this software and associated documentation
+// files (the "Software"), to deal in the Software without
+// restriction, including without limitation the rights to use, copy,
+// modify, merge, publish, distribute, sublicense, and/or sell copies
+// of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be
+// included in all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND,
+// EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF
+// MERCHANTABILITY, FITNESS FOR A PARTICULAR PURPOSE AND
+// NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT HOLDERS
+// BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER IN AN
+// ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN
+// CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package kraken
+
+import (
+	"encoding/json"
+	"sort"
+	"strconv"
+	"time"
+
+	"gitlab.com/crankykernel/cryptotrader/util"
+)
+
+// The maximum number of ledger entries Kraken will return per request.
+const ledgerPageSize = 50
+
+type LedgerEntry struct {
+	LedgerID    string
+	ReferenceID string
+	Timestamp   time.Time
+	Type        string
+	AssetClass  string
+	Asset       string
+	Amount      float64
+	Fee         float64
+	Balance     float64
+}
+
+type rawLedgerEntry struct {
+	ReferenceID string      `json:"refid"`
+	Time        json.Number `json:"time"`
+	Type        string      `json:"type"`
+	AssetClass  string      `json:"aclass"`
+	Asset       string      `json:"asset"`
+	Amount      string      `json:"amount"`
+	Fee         string      `json:"fee"`
+	Balance     string      `json:"balance"`
+}
+
+type ledgerResult struct {
+	Ledger map[string]rawLedgerEntry `json:"ledger"`
+	Count  int                       `json:"count"`
+}
+
+type GetLedgerOptions struct {
+	// The number of entries to return, 0 for all.
+	Count int
+
+	// The type of entries to return (trade, deposit, withdrawal, ...), empty
+	// for all.
+	Type string
+
+	// Comma separated list of assets, empty for all.
+	Asset string
+}
+
+type LedgerService struct {
+	client *Client
+}
+
+func NewLedgerService(client *Client) *LedgerService {
+	return &LedgerService{
+		client: client,
+	}
+}
+
+// Ledger returns ledger entries, newest first, paging through the ledger
+// until the requested count has been reached or there are no more entries.
+func (s *LedgerService) Ledger(options GetLedgerOptions) ([]LedgerEntry, error) {
+	entries := []LedgerEntry{}
+
+	for {
+		params := map[string]interface{}{
+			"ofs": len(entries),
+		}
+		if options.Type != "" {
+			params["type"] = options.Type
+		}
+		if options.Asset != "" {
+			params["asset"] = options.Asset
+		}
+
+		var result ledgerResult
+		if err := s.client.postAndDecode("/0/private/Ledgers", params, &result); err != nil {
+			return nil, err
+		}
+
+		page := []LedgerEntry{}
+		for id, raw := range result.Ledger {
+			entry, err := raw.toLedgerEntry(id)
+			if err != nil {
+				return nil, err
+			}
+			page = append(page, entry)
+		}
+		sort.Slice(page, func(i, j int) bool {
+			return page[i].Timestamp.After(page[j].Timestamp)
+		})
+		entries = append(entries, page...)
+
+		if options.Count > 0 && len(entries) >= options.Count {
+			entries = entries[0:options.Count]
+			break
+		}
+		if len(page) < ledgerPageSize || len(entries) >= result.Count {
+			break
+		}
+	}
+
+	return entries, nil
+}
+
+func (r rawLedgerEntry) toLedgerEntry(id string) (LedgerEntry, error) {
+	entry := LedgerEntry{
+		LedgerID:    id,
+		ReferenceID: r.ReferenceID,
+		Type:        r.Type,
+		AssetClass:  r.AssetClass,
+		Asset:       r.Asset,
+	}
+
+	timestamp, err := util.JsonNumberToTime(r.Time)
+	if err != nil {
+		return entry, err
+	}
+	entry.Timestamp = timestamp
+
+	if entry.Amount, err = strconv.ParseFloat(r.Amount, 64); err != nil {
+		return entry, err
+	}
+	if entry.Fee, err = strconv.ParseFloat(r.Fee, 64); err != nil {
+		return entry, err
+	}
+	if entry.Balance, err = strconv.ParseFloat(r.Balance, 64); err != nil {
+		return entry, err
+	}
+
+	return entry, nil
+}
@@ -0,0 +1,188 @@
+// The MIT License (MIT)
+//
+// Copyright (c) 2018 Cranky Kernel
+//
+// Permission is hereby granted, free of charge, to any person
+// obtaining a copy of this software and associated documentation
+// files (the "Software"), to deal in the Software without
+// restriction, including without limitation the rights to use, copy,
+// modify, merge, publish, distribute, sublicense, and/or sell copies
+// of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be
+// included in all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND,
+// EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF
+// MERCHANTABILITY, FITNESS FOR A PARTICULAR PURPOSE AND
+// NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT HOLDERS
+// BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER IN AN
+// ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN
+// CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package kraken
+
+import (
+	"encoding/json"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"gitlab.com/crankykernel/cryptotrader/util"
+)
+
+// The maximum number of trades Kraken will return per request.
+const tradesPageSize = 50
+
+// Balance returns the balance of each asset keyed by the Kraken asset name.
+func (c *Client) Balance() (map[string]float64, error) {
+	var result map[string]json.Number
+	if err := c.postAndDecode("/0/private/Balance", nil, &result); err != nil {
+		return nil, err
+	}
+	balances := map[string]float64{}
+	for asset, value := range result {
+		balance, err := value.Float64()
+		if err != nil {
+			return nil, err
+		}
+		balances[asset] = balance
+	}
+	return balances, nil
+}
+
+type OrderDescription struct {
+	Pair      string  `json:"pair"`
+	Type      string  `json:"type"`
+	OrderType string  `json:"ordertype"`
+	Price     float64 `json:"price,string"`
+	Price2    float64 `json:"price2,string"`
+	Order     string  `json:"order"`
+}
+
+type Order struct {
+	TransactionID  string           `json:"-"`
+	UserRef        int64            `json:"userref"`
+	Status         string           `json:"status"`
+	OpenTime       json.Number      `json:"opentm"`
+	Description    OrderDescription `json:"descr"`
+	Volume         float64          `json:"vol,string"`
+	VolumeExecuted float64          `json:"vol_exec,string"`
+	Cost           float64          `json:"cost,string"`
+	Fee            float64          `json:"fee,string"`
+	Price          float64          `json:"price,string"`
+}
+
+func (o *Order) Timestamp() time.Time {
+	timestamp, _ := util.JsonNumberToTime(o.OpenTime)
+	return timestamp
+}
+
+// OpenOrders returns all open orders sorted by open time.
+func (c *Client) OpenOrders() ([]Order, error) {
+	var result struct {
+		Open map[string]Order `json:"open"`
+	}
+	if err := c.postAndDecode("/0/private/OpenOrders", nil, &result); err != nil {
+		return nil, err
+	}
+	orders := []Order{}
+	for txid, order := range result.Open {
+		order.TransactionID = txid
+		orders = append(orders, order)
+	}
+	sort.Slice(orders, func(i, j int) bool {
+		return orders[i].Timestamp().Before(orders[j].Timestamp())
+	})
+	return orders, nil
+}
+
+type AddOrderParameters struct {
+	Pair      string
+	Type      string
+	OrderType string
+	Price     float64
+	Volume    float64
+}
+
+type AddOrderResponse struct {
+	Description struct {
+		Order string `json:"order"`
+	} `json:"descr"`
+	TransactionIDs []string `json:"txid"`
+}
+
+func (c *Client) AddOrder(order AddOrderParameters) (*AddOrderResponse, error) {
+	params := map[string]interface{}{
+		"pair":      order.Pair,
+		"type":      strings.ToLower(order.Type),
+		"ordertype": strings.ToLower(order.OrderType),
+		"volume":    strconv.FormatFloat(order.Volume, 'f', -1, 64),
+	}
+	if params["ordertype"] != "market" {
+		params["price"] = strconv.FormatFloat(order.Price, 'f', -1, 64)
+	}
+	var response AddOrderResponse
+	if err := c.postAndDecode("/0/private/AddOrder", params, &response); err != nil {
+		return nil, err
+	}
+	return &response, nil
+}
+
+func (c *Client) CancelOrder(txid string) error {
+	params := map[string]interface{}{
+		"txid": txid,
+	}
+	return c.postAndDecode("/0/private/CancelOrder", params, nil)
+}
+
+type Trade struct {
+	TransactionID      string      `json:"-"`
+	OrderTransactionID string      `json:"ordertxid"`
+	Pair               string      `json:"pair"`
+	Time               json.Number `json:"time"`
+	Type               string      `json:"type"`
+	OrderType          string      `json:"ordertype"`
+	Price              float64     `json:"price,string"`
+	Cost               float64     `json:"cost,string"`
+	Fee                float64     `json:"fee,string"`
+	Volume             float64     `json:"vol,string"`
+}
+
+func (t *Trade) Timestamp() time.Time {
+	timestamp, _ := util.JsonNumberToTime(t.Time)
+	return timestamp
+}
+
+// TradesHistory returns the complete trade history, newest first.
+func (c *Client) TradesHistory() ([]Trade, error) {
+	trades := []Trade{}
+	for {
+		params := map[string]interface{}{
+			"ofs": len(trades),
+		}
+		var result struct {
+			Trades map[string]Trade `json:"trades"`
+			Count  int              `json:"count"`
+		}
+		if err := c.postAndDecode("/0/private/TradesHistory", params, &result); err != nil {
+			return nil, err
+		}
+		page := []Trade{}
+		for txid, trade := range result.Trades {
+			trade.TransactionID = txid
+			page = append(page, trade)
+		}
+		sort.Slice(page, func(i, j int) bool {
+			return page[i].Timestamp().After(page[j].Timestamp())
+		})
+		trades = append(trades, page...)
+		if len(page) < tradesPageSize || len(trades) >= result.Count {
+			break
+		}
+	}
+	return trades, nil
+}
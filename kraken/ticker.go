@@ -0,0 +1,125 @@
+// The MIT License (MIT)
+//
+// Copyright (c) 2018 Cranky Kernel
+//
+// Permission is hereby granted, free of charge, to any person
+// obtaining a copy of this software and associated documentation
+// files (the "Software"), to deal in the Software without
+// restriction, including without limitation the rights to use, copy,
+// modify, merge, publish, distribute, sublicense, and/or sell copies
+// of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be
+// included in all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND,
+// EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF
+// MERCHANTABILITY, FITNESS FOR A PARTICULAR PURPOSE AND
+// NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT HOLDERS
+// BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER IN AN
+// ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN
+// CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package kraken
+
+import (
+	"encoding/json"
+	"strconv"
+	"strings"
+)
+
+type Ticker struct {
+	Pair string
+
+	Ask       float64
+	AskVolume float64
+	Bid       float64
+	BidVolume float64
+
+	Last       float64
+	LastVolume float64
+
+	VolumeToday float64
+	Volume24h   float64
+	VWAPToday   float64
+	VWAP24h     float64
+	TradesToday int64
+	Trades24h   int64
+	LowToday    float64
+	Low24h      float64
+	HighToday   float64
+	High24h     float64
+	Open        float64
+}
+
+// The raw ticker as returned by /0/public/Ticker. Most values are arrays of
+// strings where the first entry is for today and the second for the last 24
+// hours.
+type rawTicker struct {
+	A []string      `json:"a"`
+	B []string      `json:"b"`
+	C []string      `json:"c"`
+	V []string      `json:"v"`
+	P []string      `json:"p"`
+	T []json.Number `json:"t"`
+	L []string      `json:"l"`
+	H []string      `json:"h"`
+	O string        `json:"o"`
+}
+
+func (t *Ticker) UnmarshalJSON(b []byte) error {
+	var raw rawTicker
+	if err := json.Unmarshal(b, &raw); err != nil {
+		return err
+	}
+	t.Ask = parseFloatAt(raw.A, 0)
+	t.AskVolume = parseFloatAt(raw.A, 2)
+	t.Bid = parseFloatAt(raw.B, 0)
+	t.BidVolume = parseFloatAt(raw.B, 2)
+	t.Last = parseFloatAt(raw.C, 0)
+	t.LastVolume = parseFloatAt(raw.C, 1)
+	t.VolumeToday = parseFloatAt(raw.V, 0)
+	t.Volume24h = parseFloatAt(raw.V, 1)
+	t.VWAPToday = parseFloatAt(raw.P, 0)
+	t.VWAP24h = parseFloatAt(raw.P, 1)
+	if len(raw.T) > 1 {
+		t.TradesToday, _ = raw.T[0].Int64()
+		t.Trades24h, _ = raw.T[1].Int64()
+	}
+	t.LowToday = parseFloatAt(raw.L, 0)
+	t.Low24h = parseFloatAt(raw.L, 1)
+	t.HighToday = parseFloatAt(raw.H, 0)
+	t.High24h = parseFloatAt(raw.H, 1)
+	t.Open, _ = strconv.ParseFloat(raw.O, 64)
+	return nil
+}
+
+// Ticker returns the ticker for one or more pairs keyed by the pair name
+// Kraken responds with, which may differ from the name requested (for
+// example XBTUSD will be returned as XXBTZUSD). If no pairs are provided
+// the ticker for all pairs is returned.
+func (c *Client) Ticker(pairs ...string) (map[string]Ticker, error) {
+	params := map[string]interface{}{}
+	if len(pairs) > 0 {
+		params["pair"] = strings.Join(pairs, ",")
+	}
+	tickers := map[string]Ticker{}
+	if err := c.getAndDecode("/0/public/Ticker", params, &tickers); err != nil {
+		return nil, err
+	}
+	for pair, ticker := range tickers {
+		ticker.Pair = pair
+		tickers[pair] = ticker
+	}
+	return tickers, nil
+}
+
+func parseFloatAt(values []string, i int) float64 {
+	if i >= len(values) {
+		return 0
+	}
+	value, _ := strconv.ParseFloat(values[i], 64)
+	return value
+}
@@ -0,0 +1,157 @@
+// The MIT License (MIT)
+//
+// Copyright (c) 2018 Cranky Kernel
+//
+// Permission is hereby granted, free of charge, to any person
+// obtaining a copy of this software and associated documentation
+// files (the "Software"), to deal in the Software without
+// restriction, including without limitation the rights to use, copy,
+// modify, merge, publish, distribute, sublicense, and/or sell copies
+// of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be
+// included in all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND,
+// EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF
+// MERCHANTABILITY, FITNESS FOR A PARTICULAR PURPOSE AND
+// NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT HOLDERS
+// BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER IN AN
+// ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN
+// CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package kucoin
+
+import (
+	"fmt"
+	"time"
+
+	"gitlab.com/crankykernel/cryptotrader/util"
+)
+
+// GET /v1/order/dealt
+type Trade struct {
+	Oid             string    `json:"oid"`
+	OrderOid        string    `json:"orderOid"`
+	CoinType        string    `json:"coinType"`
+	CoinTypePair    string    `json:"coinTypePair"`
+	Direction       string    `json:"direction"`
+	DealPrice       float64   `json:"dealPrice"`
+	Amount          float64   `json:"amount"`
+	DealValue       float64   `json:"dealValue"`
+	Fee             float64   `json:"fee"`
+	FeeRate         float64   `json:"feeRate"`
+	CreatedAtMillis int64     `json:"createdAt"`
+	Timestamp       time.Time `json:"-"`
+}
+
+type DealtOrdersResponse struct {
+	Response
+	Data struct {
+		Total  int64    `json:"total"`
+		Limit  int64    `json:"limit"`
+		Page   int64    `json:"page"`
+		Trades []*Trade `json:"datas"`
+	} `json:"data"`
+}
+
+// GetDealtOrders returns a page of filled orders (trades), most recent
+// first.
+func (c *Client) GetDealtOrders(limit int, page int) (*DealtOrdersResponse, error) {
+	params := map[string]interface{}{
+		"limit": limit,
+		"page":  page,
+	}
+	var response DealtOrdersResponse
+	if err := c.getAndDecode("/v1/order/dealt", params, &response); err != nil {
+		return nil, err
+	}
+	if !response.Success {
+		return nil, fmt.Errorf("%s", response.Raw)
+	}
+	for _, trade := range response.Data.Trades {
+		trade.Timestamp = util.MillisToTime(trade.CreatedAtMillis)
+	}
+	return &response, nil
+}
+
+// GET /v1/open/tick
+type Tick struct {
+	Symbol         string  `json:"symbol"`
+	CoinType       string  `json:"coinType"`
+	CoinTypePair   string  `json:"coinTypePair"`
+	Trading        bool    `json:"trading"`
+	LastDealPrice  float64 `json:"lastDealPrice"`
+	Buy            float64 `json:"buy"`
+	Sell           float64 `json:"sell"`
+	High           float64 `json:"high"`
+	Low            float64 `json:"low"`
+	Vol            float64 `json:"vol"`
+	VolValue       float64 `json:"volValue"`
+	Change         float64 `json:"change"`
+	ChangeRate     float64 `json:"changeRate"`
+	FeeRate        float64 `json:"feeRate"`
+	DatetimeMillis int64   `json:"datetime"`
+}
+
+type TickResponse struct {
+	Response
+	Entries []Tick `json:"data"`
+}
+
+// GetTick returns the ticker for all trading pairs.
+func (c *Client) GetTick() (*TickResponse, error) {
+	var response TickResponse
+	if err := c.getAndDecode("/v1/open/tick", nil, &response); err != nil {
+		return nil, err
+	}
+	if !response.Success {
+		return nil, fmt.Errorf("%s", response.Raw)
+	}
+	return &response, nil
+}
+
+// GET /v1/account/{coin}/wallet/records
+type WalletRecord struct {
+	Oid             string  `json:"oid"`
+	CoinType        string  `json:"coinType"`
+	Type            string  `json:"type"`
+	Status          string  `json:"status"`
+	Amount          float64 `json:"amount"`
+	Fee             float64 `json:"fee"`
+	Address         string  `json:"address"`
+	OuterWalletTxid string  `json:"outerWalletTxid"`
+	Remark          string  `json:"remark"`
+	CreatedAtMillis int64   `json:"createdAt"`
+	UpdatedAtMillis int64   `json:"updatedAt"`
+}
+
+type WalletRecordsResponse struct {
+	Response
+	Data struct {
+		Total      int64          `json:"total"`
+		Limit      int64          `json:"limit"`
+		PageNos    int64          `json:"pageNos"`
+		CurrPageNo int64          `json:"currPageNo"`
+		Entries    []WalletRecord `json:"datas"`
+	} `json:"data"`
+}
+
+// WalletRecords returns a page of deposit and withdrawal records for a coin.
+// Pages start at 1.
+func (c *Client) WalletRecords(coin string, page int) (*WalletRecordsResponse, error) {
+	endpoint := fmt.Sprintf("/v1/account/%s/wallet/records", coin)
+	params := map[string]interface{}{
+		"page": page,
+	}
+	var response WalletRecordsResponse
+	if err := c.getAndDecode(endpoint, params, &response); err != nil {
+		return nil, err
+	}
+	if !response.Success {
+		return nil, fmt.Errorf("%s", response.Raw)
+	}
+	return &response, nil
+}
@@ -0,0 +1,178 @@
+// The MIT License (MIT)
+//
+// Copyright (c) 2018 Cranky Kernel
+//
+// Permission is hereby granted, free of charge, to any person
+// obtaining a copy of this software and associated documentation
+// files (the "Software"), to deal in the Software without
+// restriction, including without limitation the rights to use, copy,
+// modify, merge, publish, distribute, sublicense, and/or sell copies
+// of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be
+// included in all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND,
+// EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF
+// MERCHANTABILITY, FITNESS FOR A PARTICULAR PURPOSE AND
+// NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT HOLDERS
+// BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER IN AN
+// ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN
+// CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package kucoin
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"gitlab.com/crankykernel/cryptotrader/util"
+)
+
+const API_ROOT = "https://api.kucoin.com"
+
+type Client struct {
+	apiKey    string
+	apiSecret string
+}
+
+func NewClient(apiKey string, apiSecret string) *Client {
+	return &Client{
+		apiKey:    apiKey,
+		apiSecret: apiSecret,
+	}
+}
+
+// Response contains the fields common to all KuCoin responses.
+type Response struct {
+	Success   bool   `json:"success"`
+	Code      string `json:"code"`
+	Message   string `json:"msg"`
+	Timestamp int64  `json:"timestamp"`
+
+	// The raw response body.
+	Raw string `json:"-"`
+}
+
+func (r *Response) setRaw(raw []byte) {
+	r.Raw = string(raw)
+}
+
+type rawSetter interface {
+	setRaw([]byte)
+}
+
+// Get performs a GET request. If the client has an API key the request will
+// be signed.
+func (c *Client) Get(endpoint string, params map[string]interface{}) (*http.Response, error) {
+	url := fmt.Sprintf("%s%s", API_ROOT, endpoint)
+	queryString := buildQueryString(params)
+	if queryString != "" {
+		url = fmt.Sprintf("%s?%s", url, queryString)
+	}
+
+	request, err := http.NewRequest("GET", url, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	if c.apiKey != "" {
+		nonce := fmt.Sprintf("%d", util.TimeToMillis(time.Now()))
+		request.Header.Set("KC-API-KEY", c.apiKey)
+		request.Header.Set("KC-API-NONCE", nonce)
+		request.Header.Set("KC-API-SIGNATURE", c.sign(endpoint, nonce, queryString))
+	}
+
+	return http.DefaultClient.Do(request)
+}
+
+// Post performs a signed POST request with the params encoded as a form
+// body.
+func (c *Client) Post(endpoint string, params map[string]interface{}) (*http.Response, error) {
+	queryString := buildQueryString(params)
+
+	request, err := http.NewRequest("POST", fmt.Sprintf("%s%s", API_ROOT, endpoint),
+		strings.NewReader(queryString))
+	if err != nil {
+		return nil, err
+	}
+	request.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+	nonce := fmt.Sprintf("%d", util.TimeToMillis(time.Now()))
+	request.Header.Set("KC-API-KEY", c.apiKey)
+	request.Header.Set("KC-API-NONCE", nonce)
+	request.Header.Set("KC-API-SIGNATURE", c.sign(endpoint, nonce, queryString))
+
+	return http.DefaultClient.Do(request)
+}
+
+// sign computes the KC-API-SIGNATURE header value: the hex encoded
+// HMAC-SHA256 of the base64 encoded string "endpoint/nonce/queryString".
+func (c *Client) sign(endpoint string, nonce string, queryString string) string {
+	strForSign := fmt.Sprintf("%s/%s/%s", endpoint, nonce, queryString)
+	encoded := base64.StdEncoding.EncodeToString([]byte(strForSign))
+	mac := hmac.New(sha256.New, []byte(c.apiSecret))
+	mac.Write([]byte(encoded))
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// getAndDecode performs a GET request and decodes the response into
+// response, which should embed Response.
+func (c *Client) getAndDecode(endpoint string, params map[string]interface{}, response interface{}) error {
+	httpResponse, err := c.Get(endpoint, params)
+	if err != nil {
+		return err
+	}
+	return decodeResponse(httpResponse, response)
+}
+
+// postAndDecode performs a POST request and decodes the response into
+// response, which should embed Response.
+func (c *Client) postAndDecode(endpoint string, params map[string]interface{}, response interface{}) error {
+	httpResponse, err := c.Post(endpoint, params)
+	if err != nil {
+		return err
+	}
+	return decodeResponse(httpResponse, response)
+}
+
+func decodeResponse(httpResponse *http.Response, response interface{}) error {
+	body, err := util.ReadAll(httpResponse)
+	if err != nil {
+		return err
+	}
+
+	if err := json.Unmarshal(body, response); err != nil {
+		if httpResponse.StatusCode >= 400 {
+			return fmt.Errorf("%s: %s", httpResponse.Status, string(body))
+		}
+		return err
+	}
+
+	if setter, ok := response.(rawSetter); ok {
+		setter.setRaw(body)
+	}
+
+	return nil
+}
+
+// buildQueryString encodes params as a query string. KuCoin requires the
+// parameters to be sorted for the signature, which url.Values.Encode does
+// by key.
+func buildQueryString(params map[string]interface{}) string {
+	values := url.Values{}
+	for key, value := range params {
+		values.Set(key, fmt.Sprintf("%v", value))
+	}
+	return values.Encode()
+}
@@ -0,0 +1,275 @@
+// The MIT License (MIT)
+//
+// Copyright (c) 2018 Cranky Kernel
+//
+// Permission is hereby granted, free of charge, to any person
+// obtaining a copy of this software and associated documentation
+// files (the "Software"), to deal in the Software without
+// restriction, including without limitation the rights to use, copy,
+// modify, merge, publish, distribute, sublicense, and/or sell copies
+// of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be
+// included in all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND,
+// EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF
+// MERCHANTABILITY, FITNESS FOR A PARTICULAR PURPOSE AND
+// NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT HOLDERS
+// BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER IN AN
+// ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN
+// CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package kucoin
+
+import (
+	"fmt"
+
+	"gitlab.com/crankykernel/cryptotrader/core"
+	"gitlab.com/crankykernel/cryptotrader/util"
+)
+
+// Exchange adapts a Client to the core.Exchange interface. Symbols are in
+// the KuCoin form of COINTYPE-COINTYPEPAIR (ie: ETH-BTC).
+type Exchange struct {
+	client *Client
+}
+
+func NewExchange(client *Client) *Exchange {
+	return &Exchange{
+		client: client,
+	}
+}
+
+func (e *Exchange) Name() string {
+	return "KuCoin"
+}
+
+func (e *Exchange) GetSymbols() ([]core.Symbol, error) {
+	response, err := e.client.GetTick()
+	if err != nil {
+		return nil, err
+	}
+	symbols := []core.Symbol{}
+	for _, tick := range response.Entries {
+		symbols = append(symbols, core.Symbol{
+			Name:       tick.Symbol,
+			BaseAsset:  tick.CoinType,
+			QuoteAsset: tick.CoinTypePair,
+		})
+	}
+	return symbols, nil
+}
+
+func (e *Exchange) GetTickers(symbols ...string) ([]core.Ticker, error) {
+	response, err := e.client.GetTick()
+	if err != nil {
+		return nil, err
+	}
+	tickers := []core.Ticker{}
+	for _, tick := range response.Entries {
+		if len(symbols) > 0 && !containsString(symbols, tick.Symbol) {
+			continue
+		}
+		tickers = append(tickers, core.Ticker{
+			Exchange:  e.Name(),
+			Symbol:    tick.Symbol,
+			Timestamp: util.MillisToTime(tick.DatetimeMillis),
+			Last:      tick.LastDealPrice,
+			Bid:       tick.Buy,
+			Ask:       tick.Sell,
+			Volume:    tick.Vol,
+		})
+	}
+	return tickers, nil
+}
+
+func (e *Exchange) GetBalances() ([]core.Balance, error) {
+	balances := []core.Balance{}
+	for page := 1; ; page++ {
+		response, err := e.client.GetBalances(page)
+		if err != nil {
+			return nil, err
+		}
+		for _, balance := range response.Data.Entries {
+			if balance.Balance == 0 && balance.FreezeBalance == 0 {
+				continue
+			}
+			balances = append(balances, core.Balance{
+				Asset:  balance.CoinType,
+				Free:   balance.Balance,
+				Locked: balance.FreezeBalance,
+			})
+		}
+		if response.Data.CurrPageNo >= response.Data.PageNos {
+			break
+		}
+	}
+	return balances, nil
+}
+
+func (e *Exchange) GetOpenOrders(symbol string) ([]core.Order, error) {
+	response, err := e.client.GetActiveOrders(symbol)
+	if err != nil {
+		return nil, err
+	}
+	orders := []core.Order{}
+	for _, order := range append(response.Data.Buy, response.Data.Sell...) {
+		orders = append(orders, core.Order{
+			Exchange:       e.Name(),
+			Symbol:         fmt.Sprintf("%s-%s", order.CoinType, order.CoinTypePair),
+			OrderId:        order.Oid,
+			ClientOrderId:  order.UserOid,
+			Side:           core.OrderSide(order.Direction),
+			Type:           core.OrderTypeLimit,
+			Status:         "OPEN",
+			Price:          order.Price,
+			Quantity:       order.DealAmount + order.PendingAmount,
+			FilledQuantity: order.DealAmount,
+			Timestamp:      order.Timestamp(),
+		})
+	}
+	return orders, nil
+}
+
+// PlaceOrder places a limit order. KuCoin does not support market orders.
+func (e *Exchange) PlaceOrder(request core.OrderRequest) (*core.Order, error) {
+	if request.Type != core.OrderTypeLimit {
+		return nil, core.ErrNotSupported
+	}
+	response, err := e.client.CreateOrder(request.Symbol, string(request.Side),
+		request.Price, request.Quantity)
+	if err != nil {
+		return nil, err
+	}
+	return &core.Order{
+		Exchange:  e.Name(),
+		Symbol:    request.Symbol,
+		OrderId:   response.Data.OrderOid,
+		Side:      request.Side,
+		Type:      request.Type,
+		Status:    "OPEN",
+		Price:     request.Price,
+		Quantity:  request.Quantity,
+		Timestamp: util.MillisToTime(response.Timestamp),
+	}, nil
+}
+
+// CancelOrder cancels an order. As KuCoin requires the order direction the
+// open orders for the symbol are looked up first.
+func (e *Exchange) CancelOrder(symbol string, orderId string) error {
+	orders, err := e.GetOpenOrders(symbol)
+	if err != nil {
+		return err
+	}
+	for _, order := range orders {
+		if order.OrderId == orderId {
+			return e.client.CancelOrder(symbol, orderId, string(order.Side))
+		}
+	}
+	return fmt.Errorf("kucoin: open order %s not found", orderId)
+}
+
+func (e *Exchange) GetTrades(symbol string) ([]core.Trade, error) {
+	trades := []core.Trade{}
+	for page := 1; ; page++ {
+		response, err := e.client.GetDealtOrders(100, page)
+		if err != nil {
+			return nil, err
+		}
+		if !response.Success {
+			return nil, fmt.Errorf("%s", response.Raw)
+		}
+		for _, trade := range response.Data.Trades {
+			tradeSymbol := fmt.Sprintf("%s-%s", trade.CoinType, trade.CoinTypePair)
+			if symbol != "" && symbol != tradeSymbol {
+				continue
+			}
+			feeAsset := trade.CoinTypePair
+			if trade.Direction == "BUY" {
+				feeAsset = trade.CoinType
+			}
+			trades = append(trades, core.Trade{
+				Exchange:  e.Name(),
+				Symbol:    tradeSymbol,
+				TradeId:   trade.Oid,
+				OrderId:   trade.OrderOid,
+				Side:      core.OrderSide(trade.Direction),
+				Price:     trade.DealPrice,
+				Quantity:  trade.Amount,
+				Fee:       trade.Fee,
+				FeeAsset:  feeAsset,
+				Timestamp: trade.Timestamp,
+			})
+		}
+		if len(response.Data.Trades) == 0 || int64(page*100) >= response.Data.Total {
+			break
+		}
+	}
+	return trades, nil
+}
+
+// GetTransfers returns the wallet records for an asset. If no asset is
+// provided, records for every coin listed by the ticker are returned which
+// may take a while.
+func (e *Exchange) GetTransfers(asset string) ([]core.Transfer, error) {
+	coins := []string{}
+	if asset != "" {
+		coins = append(coins, asset)
+	} else {
+		ticks, err := e.client.GetTick()
+		if err != nil {
+			return nil, err
+		}
+		seen := map[string]bool{}
+		for _, tick := range ticks.Entries {
+			if !seen[tick.CoinType] {
+				seen[tick.CoinType] = true
+				coins = append(coins, tick.CoinType)
+			}
+		}
+	}
+
+	transfers := []core.Transfer{}
+	for _, coin := range coins {
+		for page := 1; ; page++ {
+			response, err := e.client.WalletRecords(coin, page)
+			if err != nil {
+				return nil, err
+			}
+			if len(response.Data.Entries) == 0 {
+				break
+			}
+			for _, entry := range response.Data.Entries {
+				transferType := core.TransferTypeDeposit
+				if entry.Type == "WITHDRAW" {
+					transferType = core.TransferTypeWithdrawal
+				}
+				transfers = append(transfers, core.Transfer{
+					Exchange:   e.Name(),
+					Asset:      entry.CoinType,
+					TransferId: entry.Oid,
+					Type:       transferType,
+					Status:     entry.Status,
+					Amount:     entry.Amount,
+					Fee:        entry.Fee,
+					Address:    entry.Address,
+					TxId:       entry.OuterWalletTxid,
+					Timestamp:  util.MillisToTime(entry.CreatedAtMillis),
+				})
+			}
+		}
+	}
+	return transfers, nil
+}
+
+func containsString(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,151 @@
+// The MIT License (MIT)
+//
+// Copyright (c) 2018 Cranky Kernel
+//
+// Permission is hereby granted, free of charge, to any person
+// obtaining a copy of this software and associated documentation
+// files (the "Software"), to deal in the Software without
+// restriction, including without limitation the rights to use, copy,
+// modify, merge, publish, distribute, sublicense, and/or sell copies
+// of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be
+// included in all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND,
+// EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF
+// MERCHANTABILITY, FITNESS FOR A PARTICULAR PURPOSE AND
+// NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT HOLDERS
+// BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER IN AN
+// ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN
+// CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package kucoin
+
+import (
+	"fmt"
+	"time"
+
+	"gitlab.com/crankykernel/cryptotrader/util"
+)
+
+// GET /v1/account/balance
+type Balance struct {
+	CoinType      string  `json:"coinType"`
+	Balance       float64 `json:"balance"`
+	FreezeBalance float64 `json:"freezeBalance"`
+}
+
+type BalancesResponse struct {
+	Response
+	Data struct {
+		Total      int64     `json:"total"`
+		Limit      int64     `json:"limit"`
+		PageNos    int64     `json:"pageNos"`
+		CurrPageNo int64     `json:"currPageNo"`
+		Entries    []Balance `json:"datas"`
+	} `json:"data"`
+}
+
+// GetBalances returns a page of balances. Pages start at 1.
+func (c *Client) GetBalances(page int) (*BalancesResponse, error) {
+	params := map[string]interface{}{
+		"limit": 20,
+		"page":  page,
+	}
+	var response BalancesResponse
+	if err := c.getAndDecode("/v1/account/balance", params, &response); err != nil {
+		return nil, err
+	}
+	if !response.Success {
+		return nil, fmt.Errorf("%s", response.Raw)
+	}
+	return &response, nil
+}
+
+// GET /v1/order/active-map
+type ActiveOrder struct {
+	Oid             string  `json:"oid"`
+	UserOid         string  `json:"userOid"`
+	CoinType        string  `json:"coinType"`
+	CoinTypePair    string  `json:"coinTypePair"`
+	Direction       string  `json:"direction"`
+	Price           float64 `json:"price"`
+	DealAmount      float64 `json:"dealAmount"`
+	PendingAmount   float64 `json:"pendingAmount"`
+	CreatedAtMillis int64   `json:"createdAt"`
+}
+
+func (o *ActiveOrder) Timestamp() time.Time {
+	return util.MillisToTime(o.CreatedAtMillis)
+}
+
+type ActiveOrdersResponse struct {
+	Response
+	Data struct {
+		Buy  []ActiveOrder `json:"BUY"`
+		Sell []ActiveOrder `json:"SELL"`
+	} `json:"data"`
+}
+
+// GetActiveOrders returns the open orders for a symbol (ie: ETH-BTC), or
+// all symbols if symbol is empty.
+func (c *Client) GetActiveOrders(symbol string) (*ActiveOrdersResponse, error) {
+	params := map[string]interface{}{}
+	if symbol != "" {
+		params["symbol"] = symbol
+	}
+	var response ActiveOrdersResponse
+	if err := c.getAndDecode("/v1/order/active-map", params, &response); err != nil {
+		return nil, err
+	}
+	if !response.Success {
+		return nil, fmt.Errorf("%s", response.Raw)
+	}
+	return &response, nil
+}
+
+type CreateOrderResponse struct {
+	Response
+	Data struct {
+		OrderOid string `json:"orderOid"`
+	} `json:"data"`
+}
+
+// CreateOrder places a limit order. Direction is BUY or SELL.
+func (c *Client) CreateOrder(symbol string, direction string, price float64, amount float64) (*CreateOrderResponse, error) {
+	params := map[string]interface{}{
+		"symbol": symbol,
+		"type":   direction,
+		"price":  price,
+		"amount": amount,
+	}
+	var response CreateOrderResponse
+	if err := c.postAndDecode("/v1/order", params, &response); err != nil {
+		return nil, err
+	}
+	if !response.Success {
+		return nil, fmt.Errorf("%s", response.Raw)
+	}
+	return &response, nil
+}
+
+// CancelOrder cancels an order. KuCoin requires the direction (BUY or SELL)
+// of the order being cancelled.
+func (c *Client) CancelOrder(symbol string, orderOid string, direction string) error {
+	params := map[string]interface{}{
+		"symbol":   symbol,
+		"orderOid": orderOid,
+		"type":     direction,
+	}
+	var response Response
+	if err := c.postAndDecode("/v1/cancel-order", params, &response); err != nil {
+		return err
+	}
+	if !response.Success {
+		return fmt.Errorf("%s", response.Raw)
+	}
+	return nil
+}
@@ -0,0 +1,181 @@
+// The MIT License (MIT)
+//
+// Copyright (c) 2018 Cranky Kernel
+//
+// Permission is hereby granted, free of charge, to any person
+// obtaining a copy of this software and associated documentation
+// files (the "Software"), to deal in the Software without
+// restriction, including without limitation the rights to use, copy,
+// modify, merge, publish, distribute, sublicense, and/or sell copies
+// of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be
+// included in all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND,
+// EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF
+// MERCHANTABILITY, FITNESS FOR A PARTICULAR PURPOSE AND
+// NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT HOLDERS
+// BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER IN AN
+// ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN
+// CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package quadriga
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"sort"
+	"sync"
+	"time"
+
+	"gitlab.com/crankykernel/cryptotrader/util"
+)
+
+const API_ROOT = "https://api.quadrigacx.com"
+
+type Client struct {
+	clientId  string
+	apiKey    string
+	apiSecret string
+
+	nonceLock sync.Mutex
+	lastNonce int64
+}
+
+func NewClient(clientId string, apiKey string, apiSecret string) *Client {
+	return &Client{
+		clientId:  clientId,
+		apiKey:    apiKey,
+		apiSecret: apiSecret,
+	}
+}
+
+// Get performs an unauthenticated GET request, used for the public
+// endpoints.
+func (c *Client) Get(endpoint string, params map[string]interface{}) (*http.Response, error) {
+	url := fmt.Sprintf("%s%s", API_ROOT, endpoint)
+	if queryString := buildQueryString(params); queryString != "" {
+		url = fmt.Sprintf("%s?%s", url, queryString)
+	}
+
+	request, err := http.NewRequest("GET", url, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return http.DefaultClient.Do(request)
+}
+
+// Post performs a POST request with the params encoded as a JSON body. If
+// the client has an API key the key, nonce and signature are added to the
+// body.
+func (c *Client) Post(endpoint string, params map[string]interface{}) (*http.Response, error) {
+	body := map[string]interface{}{}
+	for key, value := range params {
+		body[key] = value
+	}
+
+	if c.apiKey != "" {
+		nonce := c.nonce()
+		body["key"] = c.apiKey
+		body["nonce"] = nonce
+		body["signature"] = c.sign(nonce)
+	}
+
+	buf, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+
+	request, err := http.NewRequest("POST",
+		fmt.Sprintf("%s%s", API_ROOT, endpoint), bytes.NewReader(buf))
+	if err != nil {
+		return nil, err
+	}
+	request.Header.Set("Content-Type", "application/json")
+
+	return http.DefaultClient.Do(request)
+}
+
+// sign returns the lowercase hex encoded HMAC-SHA256 of the nonce, client
+// ID and API key, keyed with the API secret.
+func (c *Client) sign(nonce int64) string {
+	mac := hmac.New(sha256.New, []byte(c.apiSecret))
+	mac.Write([]byte(fmt.Sprintf("%d%s%s", nonce, c.clientId, c.apiKey)))
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+func (c *Client) nonce() int64 {
+	c.nonceLock.Lock()
+	defer c.nonceLock.Unlock()
+	nonce := util.TimeToMillis(time.Now())
+	if nonce <= c.lastNonce {
+		nonce = c.lastNonce + 1
+	}
+	c.lastNonce = nonce
+	return nonce
+}
+
+type Ticker struct {
+	High      float64 `json:"high,string"`
+	Last      float64 `json:"last,string"`
+	Timestamp int64   `json:"timestamp,string"`
+	Volume    float64 `json:"volume,string"`
+	Vwap      float64 `json:"vwap,string"`
+	Low       float64 `json:"low,string"`
+	Ask       float64 `json:"ask,string"`
+	Bid       float64 `json:"bid,string"`
+}
+
+// Tickers returns the ticker for every book, keyed by book name.
+func (c *Client) Tickers() (map[string]Ticker, error) {
+	response, err := c.Get("/v2/ticker", map[string]interface{}{
+		"book": "all",
+	})
+	if err != nil {
+		return nil, err
+	}
+	body, err := util.ReadAll(response)
+	if err != nil {
+		return nil, err
+	}
+	if response.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("%s: %s", response.Status, string(body))
+	}
+	tickers := map[string]Ticker{}
+	if err := json.Unmarshal(body, &tickers); err != nil {
+		return nil, err
+	}
+	return tickers, nil
+}
+
+// Books returns the names of the available order books (ie: btc_cad).
+func (c *Client) Books() ([]string, error) {
+	tickers, err := c.Tickers()
+	if err != nil {
+		return nil, err
+	}
+	books := []string{}
+	for book := range tickers {
+		books = append(books, book)
+	}
+	sort.Strings(books)
+	return books, nil
+}
+
+func buildQueryString(params map[string]interface{}) string {
+	values := url.Values{}
+	for key, value := range params {
+		values.Set(key, fmt.Sprintf("%v", value))
+	}
+	return values.Encode()
+}
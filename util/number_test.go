@@ -0,0 +1,49 @@
+package util
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestToFloat64(t *testing.T) {
+	tests := []interface{}{
+		1.5,
+		"1.5",
+		json.Number("1.5"),
+	}
+	for _, test := range tests {
+		value, err := ToFloat64(test)
+		if err != nil {
+			t.Fatalf("%v: %v", test, err)
+		}
+		if value != 1.5 {
+			t.Errorf("%v: expected 1.5, got %f", test, value)
+		}
+	}
+
+	if _, err := ToFloat64(nil); err == nil {
+		t.Errorf("expected error for nil")
+	}
+	if _, err := ToFloat64(true); err == nil {
+		t.Errorf("expected error for bool")
+	}
+}
+
+func TestToInt64(t *testing.T) {
+	tests := []interface{}{
+		int64(42),
+		42,
+		float64(42),
+		"42",
+		json.Number("42"),
+	}
+	for _, test := range tests {
+		value, err := ToInt64(test)
+		if err != nil {
+			t.Fatalf("%v: %v", test, err)
+		}
+		if value != 42 {
+			t.Errorf("%v: expected 42, got %d", test, value)
+		}
+	}
+}
@@ -0,0 +1,111 @@
+// The MIT License (MIT)
+//
+// Copyright (c) 2018 Cranky Kernel
+//
+// Permission is hereby granted, free of charge, to any person
+// obtaining a copy of this software and associated documentation
+// files (the "Software"), to deal in the Software without
+// restriction, including without limitation the rights to use, copy,
+// modify, merge, publish, distribute, sublicense, and/or sell copies
+// of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be
+// included in all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND,
+// EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF
+// MERCHANTABILITY, FITNESS FOR A PARTICULAR PURPOSE AND
+// NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT HOLDERS
+// BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER IN AN
+// ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN
+// CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package util
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// MillisToTime converts milliseconds since the epoch to a time.Time.
+func MillisToTime(millis int64) time.Time {
+	return time.Unix(0, millis*int64(time.Millisecond))
+}
+
+// TimeToMillis converts a time.Time to milliseconds since the epoch.
+func TimeToMillis(t time.Time) int64 {
+	return t.UnixNano() / int64(time.Millisecond)
+}
+
+// JsonNumberToTime converts a JSON number of seconds since the epoch, with
+// an optional fractional part (ie: 1517276414.1234), to a time.Time. The
+// fractional part is parsed exactly rather than through a float64.
+func JsonNumberToTime(number json.Number) (time.Time, error) {
+	return SecondsStringToTime(number.String())
+}
+
+// SecondsStringToTime converts a string of seconds since the epoch, with an
+// optional fractional part, to a time.Time. A sign applies to the whole
+// value, so "-1.5" is 1.5 seconds before the epoch.
+func SecondsStringToTime(value string) (time.Time, error) {
+	unsigned := strings.TrimPrefix(value, "-")
+	negative := len(unsigned) < len(value)
+	parts := strings.SplitN(unsigned, ".", 2)
+	// Only a single sign is allowed.
+	if strings.HasPrefix(parts[0], "-") {
+		return time.Time{}, fmt.Errorf("invalid timestamp: %s", value)
+	}
+	if negative && strings.HasPrefix(parts[0], "+") {
+		return time.Time{}, fmt.Errorf("invalid timestamp: %s", value)
+	}
+	seconds, err := strconv.ParseInt(parts[0], 10, 64)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid timestamp: %s", value)
+	}
+	var nanos int64
+	if len(parts) == 2 {
+		fraction := parts[1]
+		if strings.Trim(fraction, "0123456789") != "" {
+			return time.Time{}, fmt.Errorf("invalid timestamp: %s", value)
+		}
+		if len(fraction) > 9 {
+			fraction = fraction[0:9]
+		}
+		fraction = fraction + strings.Repeat("0", 9-len(fraction))
+		nanos, err = strconv.ParseInt(fraction, 10, 64)
+		if err != nil {
+			return time.Time{}, fmt.Errorf("invalid timestamp: %s", value)
+		}
+	}
+	if negative {
+		return time.Unix(-seconds, -nanos), nil
+	}
+	return time.Unix(seconds, nanos), nil
+}
+
+// ParseTime parses a time given on the command line as milliseconds since
+// the epoch, an RFC3339 time, or a UTC date and optional time in the form
+// 2006-01-02 15:04:05.
+func ParseTime(value string) (time.Time, error) {
+	if millis, err := strconv.ParseInt(value, 10, 64); err == nil {
+		return MillisToTime(millis), nil
+	}
+	layouts := []string{
+		time.RFC3339Nano,
+		"2006-01-02 15:04:05",
+		"2006-01-02T15:04:05",
+		"2006-01-02 15:04",
+		"2006-01-02",
+	}
+	for _, layout := range layouts {
+		if t, err := time.ParseInLocation(layout, value, time.UTC); err == nil {
+			return t, nil
+		}
+	}
+	return time.Time{}, fmt.Errorf("invalid time: %s", value)
+}
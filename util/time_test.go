@@ -0,0 +1,77 @@
+package util
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+)
+
+func TestMillisToTime(t *testing.T) {
+	ts := MillisToTime(1525367516316)
+	if ts.Unix() != 1525367516 {
+		t.Fatalf("unexpected seconds: %d", ts.Unix())
+	}
+	if ts.Nanosecond() != 316*int(time.Millisecond) {
+		t.Fatalf("unexpected nanoseconds: %d", ts.Nanosecond())
+	}
+	if TimeToMillis(ts) != 1525367516316 {
+		t.Fatalf("round trip failed: %d", TimeToMillis(ts))
+	}
+}
+
+func TestJsonNumberToTime(t *testing.T) {
+	tests := []struct {
+		number json.Number
+		secs   int64
+		nanos  int
+	}{
+		{"1517276414", 1517276414, 0},
+		{"1517276414.1234", 1517276414, 123400000},
+		{"1517276414.0001", 1517276414, 100000},
+		{"1517276414.1234567891", 1517276414, 123456789},
+		{"-1.5", -2, 500000000},
+		{"-0.25", -1, 750000000},
+		{"-3", -3, 0},
+	}
+	for _, test := range tests {
+		ts, err := JsonNumberToTime(test.number)
+		if err != nil {
+			t.Fatalf("%s: %v", test.number, err)
+		}
+		if ts.Unix() != test.secs || ts.Nanosecond() != test.nanos {
+			t.Errorf("%s: got %d.%09d", test.number, ts.Unix(), ts.Nanosecond())
+		}
+	}
+
+	for _, number := range []json.Number{"bad", "", "-", "--1", "-+1", ".5", "1.-5"} {
+		if _, err := JsonNumberToTime(number); err == nil {
+			t.Errorf("expected error for invalid number %q", number)
+		}
+	}
+}
+
+func TestParseTime(t *testing.T) {
+	tests := []struct {
+		value  string
+		millis int64
+	}{
+		{"1525367516316", 1525367516316},
+		{"2018-05-03", 1525305600000},
+		{"2018-05-03 17:11:56", 1525367516000},
+		{"2018-05-03T17:11:56Z", 1525367516000},
+		{"2018-05-03T19:11:56.316+02:00", 1525367516316},
+	}
+	for _, test := range tests {
+		ts, err := ParseTime(test.value)
+		if err != nil {
+			t.Errorf("%s: %v", test.value, err)
+			continue
+		}
+		if TimeToMillis(ts) != test.millis {
+			t.Errorf("%s: expected %d, got %d", test.value, test.millis, TimeToMillis(ts))
+		}
+	}
+	if _, err := ParseTime("yesterday"); err == nil {
+		t.Errorf("expected error for invalid time")
+	}
+}